@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &apiKey{}
+	_ resource.ResourceWithConfigure  = &apiKey{}
+	_ resource.ResourceWithModifyPlan = &apiKey{}
+)
+
+// generateAPIKeyPath is the API path that regenerates an API key of the Azure Naming Tool.
+const generateAPIKeyPath = "/api/Admin/GenerateAPIKey"
+
+// API key types of the key_type attribute.
+const (
+	apiKeyFullAccess     = "full_access"
+	apiKeyNameGeneration = "name_generation"
+	apiKeyReadOnly       = "read_only"
+)
+
+// apiKeyTypes maps the API key types to the names used by the tool.
+var apiKeyTypes = map[string]string{
+	apiKeyFullAccess:     "fullaccess",
+	apiKeyNameGeneration: "namegeneration",
+	apiKeyReadOnly:       "readonly",
+}
+
+// NewAPIKey is a helper function to simplify the provider implementation.
+func NewAPIKey() resource.Resource {
+	return &apiKey{}
+}
+
+// apiKey is the resource implementation.
+type apiKey struct {
+	client *azurenamingtool.Client
+}
+
+// apiKeyModel maps the resource schema data.
+type apiKeyModel struct {
+	ID              types.String `tfsdk:"id"`
+	KeyType         types.String `tfsdk:"key_type"`
+	RotationTrigger types.String `tfsdk:"rotation_trigger"`
+	Key             types.String `tfsdk:"key"`
+	RotatedAt       types.String `tfsdk:"rotated_at"`
+}
+
+// Metadata returns the resource type name.
+func (r *apiKey) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_key"
+}
+
+// Schema defines the schema for the resource.
+func (r *apiKey) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Regenerates an API key of the Azure Naming Tool and exposes the new key.",
+		MarkdownDescription: "Regenerates an API key of the Azure Naming Tool and exposes the new key.\n\n" +
+			"Creating the resource, or replacing it when `key_type` or `rotation_trigger` changes, regenerates the key, " +
+			"which invalidates the previous key immediately. Clients of the tool, including this provider when it uses the " +
+			"rotated key, must be given the new key before their next request. Destroying the resource only removes it from " +
+			"the state; the key stays valid. Each key type can only be managed by one resource of the configuration. " +
+			"Regenerating keys requires the `admin_password` provider setting.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:   "The type of the regenerated key.",
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"key_type": schema.StringAttribute{
+				Description: "The key to regenerate. One of 'full_access', 'name_generation' or 'read_only'. " +
+					"Changing it regenerates the new type of key.",
+				MarkdownDescription: "The key to regenerate. One of `full_access`, `name_generation` or `read_only`. " +
+					"Changing it regenerates the new type of key.",
+				Required:      true,
+				Validators:    []validator.String{StringOneOf(apiKeyFullAccess, apiKeyNameGeneration, apiKeyReadOnly)},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"rotation_trigger": schema.StringAttribute{
+				Description: "Arbitrary value that regenerates the key whenever it changes, " +
+					"such as the id of a time_rotating resource to rotate the key on a schedule.",
+				MarkdownDescription: "Arbitrary value that regenerates the key whenever it changes, " +
+					"such as the `id` of a `time_rotating` resource to rotate the key on a schedule.",
+				Optional:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"key": schema.StringAttribute{
+				Description:   "The regenerated API key.",
+				Computed:      true,
+				Sensitive:     true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"rotated_at": schema.StringAttribute{
+				Description:   "The time the key was regenerated, in RFC 3339 format.",
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+// ModifyPlan fails the plan when another resource of the configuration regenerates the same
+// type of key. Each of them would invalidate the key issued to the others during apply.
+func (r *apiKey) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Skip for destroy operations.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan apiKeyModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || plan.KeyType.IsUnknown() {
+		return
+	}
+
+	keyType := plan.KeyType.ValueString()
+	owner := fmt.Sprintf("proactnaming_api_key (key_type=%q)", keyType)
+	others, diags := plannedNames.registerPlanned(ctx, req, resp, "api_key:"+keyType, owner)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(others) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key_type"),
+			"Duplicate API Key",
+			fmt.Sprintf("The %s API key is regenerated by %d other resource(s) in this configuration:\n\n- %s\n\n"+
+				"Each regeneration invalidates the key issued to the others, so only one resource may manage each key type.",
+				keyType, len(others), strings.Join(others, "\n- ")),
+		)
+	}
+}
+
+// Create regenerates the key and sets the initial Terraform state.
+func (r *apiKey) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan apiKeyModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	key, err := regenerateAPIKey(r.client, plan.KeyType.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Regenerate API Key",
+			fmt.Sprintf("An error occurred while regenerating the %s API key: %s", plan.KeyType.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	plan.ID = plan.KeyType
+	plan.Key = types.StringValue(key)
+	plan.RotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the Terraform state. The tool does not return keys, so rotations outside of
+// Terraform cannot be detected.
+func (r *apiKey) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+}
+
+// Update sets the updated Terraform state. All configurable attributes force a new key, so only
+// the state is changed.
+func (r *apiKey) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan apiKeyModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from the Terraform state. The key stays valid.
+func (r *apiKey) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *apiKey) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*azurenamingtool.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *azurenamingtool.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// regenerateAPIKey regenerates the API key of the given type and returns the new key.
+func regenerateAPIKey(client *azurenamingtool.Client, keyType string) (string, error) {
+	toolType, ok := apiKeyTypes[keyType]
+	if !ok {
+		return "", fmt.Errorf("unknown key type %q", keyType)
+	}
+
+	var key string
+	if err := doNamingToolRequest(client, http.MethodPost, generateAPIKeyPath+"?type="+url.QueryEscape(toolType), nil, &key); err != nil {
+		return "", err
+	}
+	if key == "" {
+		return "", fmt.Errorf("the Azure Naming Tool returned an empty key")
+	}
+	return key, nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRegenerateAPIKey(t *testing.T) {
+	tool, client := newFakeNamingTool(t)
+	password := "secret"
+	tool.adminPassword = password
+	client.AdminPassword = &password
+
+	first, err := regenerateAPIKey(client, apiKeyReadOnly)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if first != tool.apiKeys["readonly"] {
+		t.Errorf("expected the read-only key %q, got %q", tool.apiKeys["readonly"], first)
+	}
+
+	// Each regeneration returns a new key.
+	second, err := regenerateAPIKey(client, apiKeyReadOnly)
+	if err != nil || second == first {
+		t.Errorf("expected a new key, got %q: %v", second, err)
+	}
+
+	if _, err := regenerateAPIKey(client, "unknown"); err == nil {
+		t.Error("expected an error for an unknown key type")
+	}
+
+	// Regenerating keys requires the admin password.
+	client.AdminPassword = nil
+	if _, err := regenerateAPIKey(client, apiKeyFullAccess); err == nil {
+		t.Error("expected an error without the admin password")
+	}
+}
+
+func TestAPIKeyModifyPlanDuplicates(t *testing.T) {
+	resetPlannedNames(t)
+	r := &apiKey{}
+
+	state := apiKeyModel{
+		ID:              types.StringValue(apiKeyReadOnly),
+		KeyType:         types.StringValue(apiKeyReadOnly),
+		RotationTrigger: types.StringValue("1"),
+		Key:             types.StringValue("key"),
+		RotatedAt:       types.StringValue("2024-01-01T00:00:00Z"),
+	}
+	config := state
+	config.ID, config.Key, config.RotatedAt = types.StringNull(), types.StringNull(), types.StringNull()
+	config.RotationTrigger = types.StringValue("2")
+	plan := config
+	plan.ID, plan.Key, plan.RotatedAt = types.StringUnknown(), types.StringUnknown(), types.StringUnknown()
+
+	// A rotation plans the resource twice without clashing with itself.
+	if _, diags := replacePlan(t, r, &state, &config, &plan); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// A second resource regenerating the same type of key is rejected.
+	if _, diags := modifyPlan(t, r, nil, &config, &plan); !diags.HasError() {
+		t.Error("expected an error for a second resource with the same key type")
+	}
+
+	// Other key types are accepted.
+	config.KeyType, plan.KeyType = types.StringValue(apiKeyFullAccess), types.StringValue(apiKeyFullAccess)
+	if _, diags := modifyPlan(t, r, nil, &config, &plan); diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
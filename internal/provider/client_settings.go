@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// Severities of the missing admin password diagnostic.
+const (
+	severityWarning = "warning"
+	severityError   = "error"
+)
+
+// clientSettings holds provider settings that are not part of the Azure Naming Tool client.
+type clientSettings struct {
+	// missingAdminPassword is the severity of the plan diagnostic for deletions without an admin password.
+	missingAdminPassword string
+
+	// readOnly and generation are copies of the client authenticating with the read-only and
+	// name generation API keys, or nil when those keys are not configured.
+	readOnly   *azurenamingtool.Client
+	generation *azurenamingtool.Client
+}
+
+// configuredSettings maps each configured client to the settings of its provider configuration.
+var configuredSettings sync.Map
+
+// setClientSettings records the settings of the provider configuration that created client.
+func setClientSettings(client *azurenamingtool.Client, settings clientSettings) {
+	configuredSettings.Store(client, settings)
+}
+
+// settingsFor returns the settings of the provider configuration that created client.
+func settingsFor(client *azurenamingtool.Client) clientSettings {
+	if settings, ok := configuredSettings.Load(client); ok {
+		return settings.(clientSettings)
+	}
+	return clientSettings{missingAdminPassword: severityWarning}
+}
+
+// readOnlyClient returns the client to use for requests that only read from the tool, such as
+// refreshes, plan checks and data sources. It authenticates with the read-only API key when one
+// is configured and falls back to client otherwise.
+func readOnlyClient(client *azurenamingtool.Client) *azurenamingtool.Client {
+	if readOnly := settingsFor(client).readOnly; readOnly != nil {
+		return readOnly
+	}
+	return client
+}
+
+// generationClient returns the client to use for name generation requests, including plan
+// previews. It authenticates with the name generation API key when one is configured and falls
+// back to client otherwise.
+func generationClient(client *azurenamingtool.Client) *azurenamingtool.Client {
+	if generation := settingsFor(client).generation; generation != nil {
+		return generation
+	}
+	return client
+}
+
+// withAPIKey returns a copy of client that authenticates with apikey, or nil when apikey is empty.
+// The copy shares the HTTP client, so that it is subject to the same concurrency limit.
+func withAPIKey(client *azurenamingtool.Client, apikey string) *azurenamingtool.Client {
+	if apikey == "" {
+		return nil
+	}
+	derived := *client
+	derived.APIKey = apikey
+	return &derived
+}
+
+// checkAdminPassword adds a diagnostic when a plan deletes a generated name but the client has
+// no admin password, so that the plan fails or warns before any other resource is changed.
+func checkAdminPassword(diags *diag.Diagnostics, client *azurenamingtool.Client, action, name string) {
+	if client == nil || (client.AdminPassword != nil && *client.AdminPassword != "") {
+		return
+	}
+
+	summary := "Missing Admin Password"
+	detail := fmt.Sprintf("This plan would %s a resource and delete the generated name %q from the Azure Naming Tool, "+
+		"which requires an admin password. Set admin_password in the provider configuration or use the PROACTNAMING_ADMIN_PASSWORD "+
+		"environment variable, otherwise the deletion fails during apply after other resources may already have been changed.", action, name)
+
+	if settingsFor(client).missingAdminPassword == severityError {
+		diags.AddError(summary, detail)
+		return
+	}
+	diags.AddWarning(summary, detail)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+func TestCheckAdminPassword(t *testing.T) {
+	empty, password := "", "secret"
+
+	tests := map[string]struct {
+		adminPassword *string
+		severity      string
+		errors        int
+		warnings      int
+	}{
+		"configured":  {adminPassword: &password, severity: severityError},
+		"nil warning": {severity: severityWarning, warnings: 1},
+		"empty error": {adminPassword: &empty, severity: severityError, errors: 1},
+		"default":     {adminPassword: &empty, warnings: 1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &azurenamingtool.Client{AdminPassword: test.adminPassword}
+			if test.severity != "" {
+				setClientSettings(client, clientSettings{missingAdminPassword: test.severity})
+			}
+
+			var diags diag.Diagnostics
+			checkAdminPassword(&diags, client, "destroy", "rg-man-webapp-001")
+
+			if got := diags.ErrorsCount(); got != test.errors {
+				t.Errorf("expected %d errors, got %d: %v", test.errors, got, diags)
+			}
+			if got := diags.WarningsCount(); got != test.warnings {
+				t.Errorf("expected %d warnings, got %d: %v", test.warnings, got, diags)
+			}
+		})
+	}
+}
+
+func TestLeastPrivilegedClients(t *testing.T) {
+	client := &azurenamingtool.Client{HostURL: "https://naming.example.com", APIKey: "full"}
+
+	// Without dedicated keys, all requests use the configured client.
+	if readOnlyClient(client) != client || generationClient(client) != client {
+		t.Error("expected the configured client without dedicated keys")
+	}
+
+	setClientSettings(client, clientSettings{
+		missingAdminPassword: severityWarning,
+		readOnly:             withAPIKey(client, "read"),
+		generation:           withAPIKey(client, ""),
+	})
+
+	readOnly := readOnlyClient(client)
+	if readOnly == client || readOnly.APIKey != "read" || readOnly.HostURL != client.HostURL {
+		t.Errorf("expected a copy of the client with the read-only key, got %+v", readOnly)
+	}
+	if client.APIKey != "full" {
+		t.Errorf("expected the configured client to keep its key, got %q", client.APIKey)
+	}
+	if generationClient(client) != client {
+		t.Error("expected the configured client without a name generation key")
+	}
+	if readOnlyClient(nil) != nil {
+		t.Error("expected no client without a configured client")
+	}
+}
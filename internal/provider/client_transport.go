@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultMaxConcurrentRequests is the default number of concurrent requests sent to the Azure Naming Tool.
+const defaultMaxConcurrentRequests = 4
+
+// limitedTransport bounds the number of concurrent requests sent to the Azure Naming Tool.
+// Identical in-flight GET requests are collapsed into a single request, and identical name
+// generation requests are serialised so that they cannot race inside the tool.
+type limitedTransport struct {
+	base    http.RoundTripper
+	slots   chan struct{}
+	timeout time.Duration
+
+	mu         sync.Mutex
+	reads      map[string]*inflightRead
+	generating map[string]*keyedLock
+}
+
+// inflightRead is a GET request shared by all callers asking for the same resource.
+type inflightRead struct {
+	done   chan struct{}
+	status int
+	header http.Header
+	body   []byte
+	err    error
+}
+
+// keyedLock is a mutex shared by all requests with the same key while any of them is pending.
+type keyedLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// newLimitedTransport returns a transport allowing at most maxConcurrent requests at a time.
+// The timeout applies to each request once it is sent, excluding the time spent waiting for a slot.
+func newLimitedTransport(base http.RoundTripper, maxConcurrent int, timeout time.Duration) *limitedTransport {
+	return &limitedTransport{
+		base:       base,
+		slots:      make(chan struct{}, maxConcurrent),
+		timeout:    timeout,
+		reads:      make(map[string]*inflightRead),
+		generating: make(map[string]*keyedLock),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch {
+	case req.Method == http.MethodGet:
+		return t.read(req)
+	case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/api/ResourceNamingRequests/RequestName"):
+		return t.generate(req)
+	}
+
+	status, header, body, err := t.send(req)
+	if err != nil {
+		return nil, err
+	}
+	return newBufferedResponse(req, status, header, body), nil
+}
+
+// read collapses identical in-flight GET requests into a single request.
+func (t *limitedTransport) read(req *http.Request) (*http.Response, error) {
+	key := req.URL.String() + "\n" + req.Header.Get("APIKey") + "\n" + req.Header.Get("AdminPassword")
+
+	t.mu.Lock()
+	call, ok := t.reads[key]
+	if !ok {
+		call = &inflightRead{done: make(chan struct{})}
+		t.reads[key] = call
+	}
+	t.mu.Unlock()
+
+	if !ok {
+		call.status, call.header, call.body, call.err = t.send(req)
+
+		t.mu.Lock()
+		delete(t.reads, key)
+		t.mu.Unlock()
+		close(call.done)
+	}
+
+	select {
+	case <-call.done:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	if call.err != nil {
+		return nil, call.err
+	}
+	return newBufferedResponse(req, call.status, call.header.Clone(), call.body), nil
+}
+
+// generate serialises name generation requests with identical bodies.
+func (t *limitedTransport) generate(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	key := string(requestBody)
+
+	t.mu.Lock()
+	lock, ok := t.generating[key]
+	if !ok {
+		lock = &keyedLock{}
+		t.generating[key] = lock
+	}
+	lock.refs++
+	t.mu.Unlock()
+
+	lock.mu.Lock()
+	status, header, body, err := t.send(req)
+	lock.mu.Unlock()
+
+	t.mu.Lock()
+	lock.refs--
+	if lock.refs == 0 {
+		delete(t.generating, key)
+	}
+	t.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	return newBufferedResponse(req, status, header, body), nil
+}
+
+// send waits for a free slot, performs the request and reads the full response body.
+func (t *limitedTransport) send(req *http.Request) (int, http.Header, []byte, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return 0, nil, nil, req.Context().Err()
+	}
+	defer func() { <-t.slots }()
+
+	ctx := req.Context()
+	if t.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+		defer cancel()
+	}
+
+	res, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	return res.StatusCode, res.Header, body, nil
+}
+
+// newBufferedResponse returns a response whose body is served from memory.
+func newBufferedResponse(req *http.Request, status int, header http.Header, body []byte) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
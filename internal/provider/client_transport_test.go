@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimitedTransport(t *testing.T) {
+	var active, maxActive, gets, overlappingGenerates int32
+	var generating sync.Map
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			peak := atomic.LoadInt32(&maxActive)
+			if current <= peak || atomic.CompareAndSwapInt32(&maxActive, peak, current) {
+				break
+			}
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			atomic.AddInt32(&gets, 1)
+		case http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			if _, loaded := generating.LoadOrStore(string(body), true); loaded {
+				atomic.AddInt32(&overlappingGenerates, 1)
+			}
+			defer generating.Delete(string(body))
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(r.Method))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newLimitedTransport(http.DefaultTransport, 2, time.Second)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			res, err := client.Get(server.URL + "/api/ResourceTypes")
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			defer res.Body.Close()
+			if body, _ := io.ReadAll(res.Body); string(body) != http.MethodGet {
+				t.Errorf("unexpected body %q", body)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			res, err := client.Post(server.URL+"/api/ResourceNamingRequests/RequestName", "application/json", strings.NewReader(`{"resourceInstance":"001"}`))
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			res.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxActive > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", maxActive)
+	}
+	if gets >= 8 {
+		t.Errorf("expected identical reads to be collapsed, got %d requests", gets)
+	}
+	if overlappingGenerates > 0 {
+		t.Errorf("expected identical generate requests to be serialised, got %d overlaps", overlappingGenerates)
+	}
+}
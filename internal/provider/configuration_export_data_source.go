@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &configurationExportDataSource{}
+	_ datasource.DataSourceWithConfigure = &configurationExportDataSource{}
+)
+
+// API paths of the configuration export and import of the Azure Naming Tool.
+const (
+	exportConfigurationPath = "/api/ImportExport/ExportConfiguration"
+	importConfigurationPath = "/api/ImportExport/ImportConfiguration"
+)
+
+// NewConfigurationExportDataSource is a helper function to simplify the provider implementation.
+func NewConfigurationExportDataSource() datasource.DataSource {
+	return &configurationExportDataSource{}
+}
+
+// configurationExportDataSource is the data source implementation.
+type configurationExportDataSource struct {
+	client *azurenamingtool.Client
+}
+
+// configurationExportDataSourceModel maps the data source schema data.
+type configurationExportDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Configuration types.String `tfsdk:"configuration"`
+	SHA256        types.String `tfsdk:"sha256"`
+}
+
+// Metadata returns the data source type name.
+func (d *configurationExportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_export"
+}
+
+// Schema defines the schema for the data source.
+func (d *configurationExportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exports the full configuration of the Azure Naming Tool as JSON.",
+		MarkdownDescription: "Exports the full configuration of the Azure Naming Tool as JSON. " +
+			"Use it with `proactnaming_configuration_import` to copy the naming conventions of one tool to another, " +
+			"or write it to a file to keep a versioned backup.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The SHA-256 hash of the configuration.",
+				Computed:    true,
+			},
+			"configuration": schema.StringAttribute{
+				Description: "The configuration as normalised JSON, with object keys sorted and without insignificant whitespace.",
+				Computed:    true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "The hex-encoded SHA-256 hash of the configuration.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *configurationExportDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	configuration, err := exportConfiguration(readOnlyClient(d.client))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Export Configuration",
+			fmt.Sprintf("An error occurred while exporting the configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	hash := configurationHash(configuration)
+	state := configurationExportDataSourceModel{
+		ID:            types.StringValue(hash),
+		Configuration: types.StringValue(configuration),
+		SHA256:        types.StringValue(hash),
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *configurationExportDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*azurenamingtool.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *azurenamingtool.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// exportConfiguration returns the configuration of the tool as normalised JSON.
+func exportConfiguration(client *azurenamingtool.Client) (string, error) {
+	var configuration json.RawMessage
+	if err := doNamingToolRequest(client, http.MethodGet, exportConfigurationPath, nil, &configuration); err != nil {
+		return "", err
+	}
+	return normalizeConfiguration(string(configuration))
+}
+
+// normalizeConfiguration returns a JSON document with object keys sorted and without insignificant
+// whitespace, so that documents differing only in formatting compare equal.
+func normalizeConfiguration(document string) (string, error) {
+	var configuration any
+	decoder := json.NewDecoder(bytes.NewReader([]byte(document)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&configuration); err != nil {
+		return "", err
+	}
+	if decoder.More() {
+		return "", fmt.Errorf("unexpected data after the JSON document")
+	}
+	normalized, err := json.Marshal(configuration)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
+// configurationHash returns the hex-encoded SHA-256 hash of a configuration.
+func configurationHash(configuration string) string {
+	sum := sha256.Sum256([]byte(configuration))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,286 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &configurationImport{}
+	_ resource.ResourceWithConfigure  = &configurationImport{}
+	_ resource.ResourceWithModifyPlan = &configurationImport{}
+)
+
+// NewConfigurationImport is a helper function to simplify the provider implementation.
+func NewConfigurationImport() resource.Resource {
+	return &configurationImport{}
+}
+
+// configurationImport is the resource implementation.
+type configurationImport struct {
+	client *azurenamingtool.Client
+}
+
+// configurationImportModel maps the resource schema data.
+type configurationImportModel struct {
+	ID            types.String `tfsdk:"id"`
+	Configuration types.String `tfsdk:"configuration"`
+	SHA256        types.String `tfsdk:"sha256"`
+}
+
+// Metadata returns the resource type name.
+func (r *configurationImport) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_import"
+}
+
+// Schema defines the schema for the resource.
+func (r *configurationImport) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Imports a full configuration into the Azure Naming Tool and detects drift against it.",
+		MarkdownDescription: "Imports a full configuration into the Azure Naming Tool and detects drift against it.\n\n" +
+			"The configuration is a JSON document as exported by the `proactnaming_configuration_export` data source. " +
+			"On refresh, the configuration of the tool is exported and compared with the document: values of the document that " +
+			"differ in the tool are reported as drift and imported again on the next apply, while settings the document does not " +
+			"contain are ignored. Destroying the resource only removes it from the state; the tool keeps the imported configuration. " +
+			"Importing requires the `admin_password` provider setting.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The SHA-256 hash of the imported configuration.",
+				Computed:    true,
+			},
+			"configuration": schema.StringAttribute{
+				Description: "The configuration to import, as a JSON document. Formatting and the order of object keys are not significant.",
+				Required:    true,
+				Validators:  []validator.String{StringNotEmpty()},
+			},
+			"sha256": schema.StringAttribute{
+				Description: "The hex-encoded SHA-256 hash of the normalised configuration, " +
+					"comparable with the sha256 attribute of the proactnaming_configuration_export data source.",
+				MarkdownDescription: "The hex-encoded SHA-256 hash of the normalised configuration, " +
+					"comparable with the `sha256` attribute of the `proactnaming_configuration_export` data source.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Create imports the configuration and sets the initial Terraform state.
+func (r *configurationImport) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan configurationImportModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	if err := importConfiguration(r.client, plan.Configuration.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Import Configuration",
+			fmt.Sprintf("An error occurred while importing the configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	plan.setHash()
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read exports the configuration of the tool and replaces the configuration in the state with it
+// when the tool no longer contains the imported values.
+func (r *configurationImport) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state configurationImportModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := exportConfiguration(readOnlyClient(r.client))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Export Configuration",
+			fmt.Sprintf("An error occurred while exporting the configuration to detect drift: %s", err.Error()),
+		)
+		return
+	}
+
+	drifted, err := configurationDrifted(state.Configuration.ValueString(), current)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Compare Configuration",
+			fmt.Sprintf("An error occurred while comparing the imported configuration with the tool: %s", err.Error()),
+		)
+		return
+	}
+	if drifted {
+		state.Configuration = types.StringValue(current)
+		state.SHA256 = types.StringValue(configurationHash(current))
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update imports the changed configuration and sets the updated Terraform state on success.
+func (r *configurationImport) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan configurationImportModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	if err := importConfiguration(r.client, plan.Configuration.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Import Configuration",
+			fmt.Sprintf("An error occurred while importing the configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	plan.setHash()
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from the Terraform state. The tool keeps the imported configuration.
+func (r *configurationImport) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// ModifyPlan validates the configuration and computes its hash, so that the plan shows it.
+func (r *configurationImport) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Skip for destroy operations.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan configurationImportModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || plan.Configuration.IsUnknown() {
+		return
+	}
+
+	normalized, err := normalizeConfiguration(plan.Configuration.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("configuration"),
+			"Invalid Configuration",
+			fmt.Sprintf("The configuration must be a JSON document: %s", err.Error()),
+		)
+		return
+	}
+
+	hash := configurationHash(normalized)
+	plan.ID = types.StringValue(hash)
+	plan.SHA256 = types.StringValue(hash)
+
+	diags = resp.Plan.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *configurationImport) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*azurenamingtool.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *azurenamingtool.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// setHash sets the ID and hash of the model from its configuration. Invalid configurations are
+// rejected during planning, so their hash is left unchanged.
+func (m *configurationImportModel) setHash() {
+	normalized, err := normalizeConfiguration(m.Configuration.ValueString())
+	if err != nil {
+		return
+	}
+	hash := configurationHash(normalized)
+	m.ID = types.StringValue(hash)
+	m.SHA256 = types.StringValue(hash)
+}
+
+// importConfiguration imports a JSON document into the tool.
+func importConfiguration(client *azurenamingtool.Client, document string) error {
+	normalized, err := normalizeConfiguration(document)
+	if err != nil {
+		return err
+	}
+	return doNamingToolRequest(client, http.MethodPost, importConfigurationPath, json.RawMessage(normalized), nil)
+}
+
+// configurationDrifted reports whether the exported configuration current no longer contains the
+// values of the imported document.
+func configurationDrifted(document, current string) (bool, error) {
+	var imported, exported any
+	for _, value := range []struct {
+		document string
+		target   *any
+	}{{document, &imported}, {current, &exported}} {
+		decoder := json.NewDecoder(strings.NewReader(value.document))
+		decoder.UseNumber()
+		if err := decoder.Decode(value.target); err != nil {
+			return false, err
+		}
+	}
+	return !configurationContains(exported, imported), nil
+}
+
+// configurationContains reports whether actual contains the values of expected. Objects may have
+// additional keys; arrays must have the same length and contain the expected elements in order.
+func configurationContains(actual, expected any) bool {
+	switch expected := expected.(type) {
+	case map[string]any:
+		actual, ok := actual.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, value := range expected {
+			if !configurationContains(actual[key], value) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		actual, ok := actual.([]any)
+		if !ok || len(actual) != len(expected) {
+			return false
+		}
+		for i := range expected {
+			if !configurationContains(actual[i], expected[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return actual == expected
+	}
+}
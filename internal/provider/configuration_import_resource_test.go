@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeConfiguration(t *testing.T) {
+	a, err := normalizeConfiguration(`{"b": [1, 2.50], "a": {"y": true, "x": "<v>"}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	b, err := normalizeConfiguration("{\"a\":{\"x\":\"<v>\",\"y\":true},\n\"b\":[1,2.50]}")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if a != b || configurationHash(a) != configurationHash(b) {
+		t.Errorf("expected documents differing in formatting to be equal, got %s and %s", a, b)
+	}
+
+	for _, document := range []string{"", "{", `{"a": 1} {"b": 2}`} {
+		if _, err := normalizeConfiguration(document); err == nil {
+			t.Errorf("expected an error for %q", document)
+		}
+	}
+}
+
+func TestConfigurationDrifted(t *testing.T) {
+	document := `{"delimiters": [{"delimiter": "-", "enabled": true}], "settings": {"retention": 30}}`
+
+	for name, test := range map[string]struct {
+		current string
+		drifted bool
+	}{
+		"identical":      {current: document},
+		"additional key": {current: `{"delimiters": [{"delimiter": "-", "enabled": true, "id": 1}], "settings": {"retention": 30}, "version": "4"}`},
+		"changed value":  {current: `{"delimiters": [{"delimiter": "-", "enabled": false}], "settings": {"retention": 30}}`, drifted: true},
+		"added element":  {current: `{"delimiters": [{"delimiter": "-", "enabled": true}, {"delimiter": "_"}], "settings": {"retention": 30}}`, drifted: true},
+		"missing key":    {current: `{"delimiters": [{"delimiter": "-", "enabled": true}]}`, drifted: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			drifted, err := configurationDrifted(document, test.current)
+			if err != nil || drifted != test.drifted {
+				t.Errorf("expected drifted %t, got %t: %v", test.drifted, drifted, err)
+			}
+		})
+	}
+}
+
+func TestConfigurationImportExport(t *testing.T) {
+	tool, client := newFakeNamingTool(t)
+	password := "secret"
+	tool.adminPassword = password
+
+	document := `{"settings": {"retention": 30}, "delimiters": ["-"]}`
+	if err := importConfiguration(client, document); err == nil {
+		t.Error("expected an error without the admin password")
+	}
+
+	client.AdminPassword = &password
+	if err := importConfiguration(client, document); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	exported, err := exportConfiguration(client)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	normalized, _ := normalizeConfiguration(document)
+	if exported != normalized {
+		t.Errorf("expected the export %s to equal the imported document %s", exported, normalized)
+	}
+
+	model := configurationImportModel{Configuration: types.StringValue(document)}
+	model.setHash()
+	if model.SHA256.ValueString() != configurationHash(exported) || model.ID != model.SHA256 {
+		t.Errorf("expected the hash of the export, got %s", model.SHA256)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// commandCredentials maps the JSON document printed by the credential command. Credentials the
+// command leaves empty are taken from the other sources.
+type commandCredentials struct {
+	APIKey           string `json:"apikey"`
+	AdminPassword    string `json:"admin_password"`
+	ReadOnlyAPIKey   string `json:"read_only_apikey"`
+	GenerationAPIKey string `json:"generation_apikey"`
+}
+
+// credentialCache holds the output of each credential command that succeeded, so that the
+// command runs once per Terraform run even when the provider is configured more than once.
+var credentialCache = struct {
+	mu      sync.Mutex
+	results map[string]commandCredentials
+}{results: make(map[string]commandCredentials)}
+
+// runCredentialCommand runs the program of command with its arguments and decodes the JSON
+// credentials it prints. The result is cached for the lifetime of the provider process.
+func runCredentialCommand(ctx context.Context, command []string) (commandCredentials, error) {
+	if len(command) == 0 || command[0] == "" {
+		return commandCredentials{}, fmt.Errorf("the command must contain at least the program to run")
+	}
+
+	key := strings.Join(command, "\x00")
+
+	credentialCache.mu.Lock()
+	defer credentialCache.mu.Unlock()
+
+	if credentials, ok := credentialCache.results[key]; ok {
+		return credentials, nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return commandCredentials{}, fmt.Errorf("%w: %s", err, message)
+		}
+		return commandCredentials{}, err
+	}
+
+	var credentials commandCredentials
+	decoder := json.NewDecoder(&stdout)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&credentials); err != nil {
+		return commandCredentials{}, fmt.Errorf("the output is not a JSON object with the fields apikey, admin_password, "+
+			"read_only_apikey and generation_apikey: %w", err)
+	}
+
+	credentialCache.results[key] = credentials
+	return credentials, nil
+}
+
+// readCredentialFile returns the content of a credential file without surrounding whitespace,
+// such as the trailing newline most editors and secret agents write.
+func readCredentialFile(name string) (string, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	credential := strings.TrimSpace(string(content))
+	if credential == "" {
+		return "", fmt.Errorf("the file %s is empty", name)
+	}
+	return credential, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunCredentialCommand(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is required to run credential commands")
+	}
+
+	counter := filepath.Join(t.TempDir(), "runs")
+	command := []string{"sh", "-c", `echo run >> "$0"; echo '{"apikey": "full", "read_only_apikey": "read"}'`, counter}
+
+	credentials, err := runCredentialCommand(context.Background(), command)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := commandCredentials{APIKey: "full", ReadOnlyAPIKey: "read"}
+	if credentials != expected {
+		t.Errorf("expected credentials %+v, got %+v", expected, credentials)
+	}
+
+	// The result is cached, so the command runs once.
+	if _, err := runCredentialCommand(context.Background(), command); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if runs, _ := os.ReadFile(counter); string(runs) != "run\n" {
+		t.Errorf("expected the command to run once, got %q", runs)
+	}
+
+	for name, command := range map[string][]string{
+		"empty":          nil,
+		"failure":        {"sh", "-c", "echo locked >&2; exit 1"},
+		"not json":       {"sh", "-c", "echo secret"},
+		"unknown fields": {"sh", "-c", `echo '{"api_key": "full"}'`},
+	} {
+		if _, err := runCredentialCommand(context.Background(), command); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestReadCredentialFile(t *testing.T) {
+	dir := t.TempDir()
+
+	name := filepath.Join(dir, "apikey")
+	if err := os.WriteFile(name, []byte("  secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if credential, err := readCredentialFile(name); err != nil || credential != "secret" {
+		t.Errorf("expected %q, got %q: %v", "secret", credential, err)
+	}
+
+	empty := filepath.Join(dir, "empty")
+	if err := os.WriteFile(empty, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readCredentialFile(empty); err == nil {
+		t.Error("expected an error for an empty file")
+	}
+	if _, err := readCredentialFile(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
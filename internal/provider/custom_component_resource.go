@@ -0,0 +1,454 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &customComponent{}
+	_ resource.ResourceWithConfigure   = &customComponent{}
+	_ resource.ResourceWithImportState = &customComponent{}
+	_ resource.ResourceWithModifyPlan  = &customComponent{}
+)
+
+// resourceComponentsPath is the API path of the name components of the Azure Naming Tool,
+// which include the custom components.
+const resourceComponentsPath = "/api/ResourceComponents"
+
+const (
+	defaultCustomComponentMinLength = 1
+	defaultCustomComponentMaxLength = 10
+)
+
+// NewCustomComponent is a helper function to simplify the provider implementation.
+func NewCustomComponent() resource.Resource {
+	return &customComponent{}
+}
+
+// customComponent is the resource implementation.
+type customComponent struct {
+	client *azurenamingtool.Client
+}
+
+// customComponentModel maps the resource schema data.
+type customComponentModel struct {
+	ID        types.Int64  `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	MinLength types.Int64  `tfsdk:"min_length"`
+	MaxLength types.Int64  `tfsdk:"max_length"`
+	SortOrder types.Int64  `tfsdk:"sort_order"`
+}
+
+// customComponentItem is a name component as exchanged with the Azure Naming Tool.
+// The tool exchanges the length limits as strings.
+type customComponentItem struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Enabled     bool   `json:"enabled"`
+	SortOrder   int64  `json:"sortOrder"`
+	IsCustom    bool   `json:"isCustom"`
+	IsFreeText  bool   `json:"isFreeText"`
+	MinLength   string `json:"minLength"`
+	MaxLength   string `json:"maxLength"`
+}
+
+// Metadata returns the resource type name.
+func (r *customComponent) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_component"
+}
+
+// Schema defines the schema for the resource.
+func (r *customComponent) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a custom name component of the Azure Naming Tool configuration.",
+		MarkdownDescription: "Manages a custom name component of the Azure Naming Tool configuration.\n\n" +
+			"The allowed values of the component are managed with `proactnaming_custom_component_value`. " +
+			"Changes to the configuration require the `admin_password` provider setting. " +
+			"When a custom component named `Application` is defined, `proactnaming_generate_name` validates its " +
+			"`application` attribute against the length limits and allowed values of the component.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description:   "The unique identifier of the custom component in the Azure Naming Tool.",
+				Computed:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the custom component (e.g., 'Application'). Must be unique among the name components, " +
+					"ignoring case and spaces. Changing the name forces a new component, which removes its values.",
+				MarkdownDescription: "Name of the custom component (e.g., `Application`). Must be unique among the name components, " +
+					"ignoring case and spaces. Changing the name forces a new component, which removes its values.",
+				Required:      true,
+				Validators:    []validator.String{StringNotEmpty()},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"min_length": schema.Int64Attribute{
+				Description: fmt.Sprintf("Minimum length of the values of the component. Defaults to %d.", defaultCustomComponentMinLength),
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultCustomComponentMinLength),
+				Validators:  []validator.Int64{Int64AtLeast(1)},
+			},
+			"max_length": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximum length of the values of the component. Defaults to %d.", defaultCustomComponentMaxLength),
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultCustomComponentMaxLength),
+				Validators:  []validator.Int64{Int64AtLeast(1)},
+			},
+			"sort_order": schema.Int64Attribute{
+				Description:   "Position of the component in generated names. Defaults to the end of the list.",
+				Optional:      true,
+				Computed:      true,
+				Validators:    []validator.Int64{Int64AtLeast(0)},
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *customComponent) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan customComponentModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	item, err := createCustomComponent(r.client, plan.item())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Custom Component",
+			fmt.Sprintf("An error occurred while creating the custom component %q: %s", plan.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	plan.setItem(item)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *customComponent) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state customComponentModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, err := listCustomComponents(readOnlyClient(r.client))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Custom Component",
+			fmt.Sprintf("An error occurred while reading custom component %d: %s", state.ID.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	// Components removed outside of Terraform are recreated on the next apply.
+	var found *customComponentItem
+	for i := range items {
+		if items[i].ID == state.ID.ValueInt64() {
+			found = &items[i]
+			break
+		}
+	}
+	if found == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.setItem(*found)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *customComponent) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state customComponentModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	plan.ID = state.ID
+	if plan.SortOrder.IsUnknown() {
+		plan.SortOrder = state.SortOrder
+	}
+
+	if err := doNamingToolRequest(r.client, http.MethodPost, resourceComponentsPath, plan.item(), nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Custom Component",
+			fmt.Sprintf("An error occurred while updating custom component %d: %s", plan.ID.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *customComponent) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state customComponentModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	err := doNamingToolRequest(r.client, http.MethodDelete, fmt.Sprintf("%s/%d", resourceComponentsPath, state.ID.ValueInt64()), nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Custom Component",
+			fmt.Sprintf("An error occurred while deleting custom component %d: %s", state.ID.ValueInt64(), err.Error()),
+		)
+	}
+}
+
+// ModifyPlan fails the plan when the length limits contradict each other or when the planned
+// name is already used by another resource of the configuration or by another name component.
+func (r *customComponent) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Skip for destroy operations.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan customComponentModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.MinLength.IsUnknown() && !plan.MaxLength.IsUnknown() && plan.MinLength.ValueInt64() > plan.MaxLength.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("min_length"),
+			"Invalid Length Limits",
+			fmt.Sprintf("The min_length %d is greater than the max_length %d.", plan.MinLength.ValueInt64(), plan.MaxLength.ValueInt64()),
+		)
+		return
+	}
+
+	if plan.Name.IsUnknown() {
+		return
+	}
+
+	name := plan.Name.ValueString()
+	owner := fmt.Sprintf("proactnaming_custom_component (name=%q)", name)
+	others, diags := plannedNames.registerPlanned(ctx, req, resp, "custom_component:"+customComponentKey(name), owner)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(others) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Duplicate Custom Component",
+			fmt.Sprintf("The custom component %q is planned by %d other resource(s) in this configuration:\n\n- %s\n\n"+
+				"Component names must be unique, ignoring case and spaces.", name, len(others), strings.Join(others, "\n- ")),
+		)
+		return
+	}
+
+	// Skip if the client is not available (shouldn't happen, but safety check).
+	if r.client == nil || !req.State.Raw.IsNull() {
+		return
+	}
+
+	items, err := listResourceComponents(readOnlyClient(r.client))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Name Components",
+			fmt.Sprintf("An error occurred while checking that the custom component %q is unique: %s", name, err.Error()),
+		)
+		return
+	}
+	if existing := findCustomComponent(items, name); existing != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Duplicate Custom Component",
+			fmt.Sprintf("The name %q is already used by the name component %q with ID %d. "+
+				"Choose another name or import the existing component.", name, existing.DisplayName, existing.ID),
+		)
+	}
+}
+
+// ImportState imports a custom component by its ID in the Azure Naming Tool.
+func (r *customComponent) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importInt64ID(ctx, req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *customComponent) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*azurenamingtool.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *azurenamingtool.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// item returns the name component described by the model. Unknown sort orders are sent as -1.
+func (m customComponentModel) item() customComponentItem {
+	sortOrder := int64(-1)
+	if !m.SortOrder.IsNull() && !m.SortOrder.IsUnknown() {
+		sortOrder = m.SortOrder.ValueInt64()
+	}
+	return customComponentItem{
+		ID:          m.ID.ValueInt64(),
+		Name:        customComponentKey(m.Name.ValueString()),
+		DisplayName: m.Name.ValueString(),
+		Enabled:     true,
+		SortOrder:   sortOrder,
+		IsCustom:    true,
+		MinLength:   strconv.FormatInt(m.MinLength.ValueInt64(), 10),
+		MaxLength:   strconv.FormatInt(m.MaxLength.ValueInt64(), 10),
+	}
+}
+
+// setItem updates the model from a name component. Length limits the tool cannot parse are kept.
+func (m *customComponentModel) setItem(item customComponentItem) {
+	m.ID = types.Int64Value(item.ID)
+	m.Name = types.StringValue(item.DisplayName)
+	m.SortOrder = types.Int64Value(item.SortOrder)
+	if minLength, err := strconv.ParseInt(item.MinLength, 10, 64); err == nil {
+		m.MinLength = types.Int64Value(minLength)
+	}
+	if maxLength, err := strconv.ParseInt(item.MaxLength, 10, 64); err == nil {
+		m.MaxLength = types.Int64Value(maxLength)
+	}
+}
+
+// lengthLimits returns the length limits of the component, falling back to the defaults of the
+// tool for limits it cannot parse.
+func (item customComponentItem) lengthLimits() (int, int) {
+	minLength, err := strconv.Atoi(item.MinLength)
+	if err != nil {
+		minLength = defaultCustomComponentMinLength
+	}
+	maxLength, err := strconv.Atoi(item.MaxLength)
+	if err != nil {
+		maxLength = defaultCustomComponentMaxLength
+	}
+	return minLength, maxLength
+}
+
+// customComponentKey returns the name the tool uses to identify a custom component and to
+// link its values, which is the display name in lower case without spaces.
+func customComponentKey(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, " ", ""))
+}
+
+// listResourceComponents returns all name components, including the built-in ones.
+func listResourceComponents(client *azurenamingtool.Client) ([]customComponentItem, error) {
+	var items []customComponentItem
+	if err := doNamingToolRequest(client, http.MethodGet, resourceComponentsPath, nil, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// listCustomComponents returns the custom name components.
+func listCustomComponents(client *azurenamingtool.Client) ([]customComponentItem, error) {
+	items, err := listResourceComponents(client)
+	if err != nil {
+		return nil, err
+	}
+
+	custom := make([]customComponentItem, 0, len(items))
+	for _, item := range items {
+		if item.IsCustom {
+			custom = append(custom, item)
+		}
+	}
+	return custom, nil
+}
+
+// findCustomComponent returns the component with the given name, or nil when there is none.
+func findCustomComponent(items []customComponentItem, name string) *customComponentItem {
+	key := customComponentKey(name)
+	for i := range items {
+		if customComponentKey(items[i].Name) == key || customComponentKey(items[i].DisplayName) == key {
+			return &items[i]
+		}
+	}
+	return nil
+}
+
+// createCustomComponent creates a custom component and returns it with the ID assigned by the
+// tool. Components without a sort order are appended to the end of the name components. The tool
+// does not return the new component, so it is looked up by its name afterwards.
+func createCustomComponent(client *azurenamingtool.Client, item customComponentItem) (customComponentItem, error) {
+	items, err := listResourceComponents(client)
+	if err != nil {
+		return item, err
+	}
+
+	if existing := findCustomComponent(items, item.DisplayName); existing != nil {
+		return item, fmt.Errorf("a name component %q already exists with ID %d, import it instead", existing.DisplayName, existing.ID)
+	}
+
+	if item.SortOrder < 0 {
+		item.SortOrder = 0
+		for _, existing := range items {
+			item.SortOrder = max(item.SortOrder, existing.SortOrder+1)
+		}
+	}
+
+	item.ID = 0
+	item.Name = customComponentKey(item.DisplayName)
+	item.Enabled = true
+	item.IsCustom = true
+	if err := doNamingToolRequest(client, http.MethodPost, resourceComponentsPath, item, nil); err != nil {
+		return item, err
+	}
+
+	items, err = listCustomComponents(client)
+	if err != nil {
+		return item, err
+	}
+	if created := findCustomComponent(items, item.DisplayName); created != nil {
+		return *created, nil
+	}
+
+	return item, fmt.Errorf("the custom component %q was not found after creating it", item.DisplayName)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCustomComponentLifecycle(t *testing.T) {
+	_, client := newFakeNamingTool(t)
+
+	created, err := createCustomComponent(client, customComponentItem{DisplayName: "Business Unit", SortOrder: -1, MinLength: "2", MaxLength: "4"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if created.ID == 0 || created.Name != "businessunit" || !created.IsCustom {
+		t.Fatalf("expected the created component to have an ID, its key as name and be custom, got %+v", created)
+	}
+
+	var model customComponentModel
+	model.setItem(created)
+	if model.Name.ValueString() != "Business Unit" || model.MinLength.ValueInt64() != 2 || model.MaxLength.ValueInt64() != 4 {
+		t.Errorf("unexpected model: %+v", model)
+	}
+
+	// Names are unique, ignoring case and spaces.
+	if _, err := createCustomComponent(client, customComponentItem{DisplayName: "businessUnit", SortOrder: -1}); err == nil {
+		t.Error("expected an error for a duplicate component name")
+	}
+
+	items, err := listCustomComponents(client)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("expected one custom component, got %+v: %v", items, err)
+	}
+	if found := findCustomComponent(items, "BUSINESS UNIT"); found == nil || found.ID != created.ID {
+		t.Errorf("expected the component to be found by name, got %+v", found)
+	}
+	if found := findCustomComponent(items, "Application"); found != nil {
+		t.Errorf("expected no Application component, got %+v", found)
+	}
+}
+
+func TestCustomComponentLengthLimits(t *testing.T) {
+	for name, test := range map[string]struct {
+		item     customComponentItem
+		min, max int
+	}{
+		"set":      {item: customComponentItem{MinLength: "2", MaxLength: "6"}, min: 2, max: 6},
+		"defaults": {item: customComponentItem{MinLength: "", MaxLength: "n/a"}, min: 1, max: 10},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if minLength, maxLength := test.item.lengthLimits(); minLength != test.min || maxLength != test.max {
+				t.Errorf("expected %d to %d, got %d to %d", test.min, test.max, minLength, maxLength)
+			}
+		})
+	}
+}
+
+func TestCustomComponentModifyPlanDuplicates(t *testing.T) {
+	resetPlannedNames(t)
+	r := &customComponent{}
+
+	state := customComponentModel{
+		ID:        types.Int64Value(1),
+		Name:      types.StringValue("Business Unit"),
+		MinLength: types.Int64Value(1),
+		MaxLength: types.Int64Value(10),
+		SortOrder: types.Int64Value(1),
+	}
+	config := state
+	config.ID = types.Int64Null()
+	config.Name = types.StringValue("Cost Center")
+	plan := config
+	plan.ID = types.Int64Unknown()
+
+	// Renaming plans the replacement twice without clashing with itself.
+	if _, diags := replacePlan(t, r, &state, &config, &plan); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// A second resource with the same name, ignoring case and spaces, is rejected.
+	config.Name, plan.Name = types.StringValue("costcenter"), types.StringValue("costcenter")
+	if _, diags := modifyPlan(t, r, nil, &config, &plan); !diags.HasError() {
+		t.Error("expected an error for a second resource with the same name")
+	}
+}
@@ -0,0 +1,502 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &customComponentValue{}
+	_ resource.ResourceWithConfigure   = &customComponentValue{}
+	_ resource.ResourceWithImportState = &customComponentValue{}
+	_ resource.ResourceWithModifyPlan  = &customComponentValue{}
+)
+
+// customComponentValuesPath is the API path of the allowed values of all custom components.
+const customComponentValuesPath = "/api/CustomComponents"
+
+// NewCustomComponentValue is a helper function to simplify the provider implementation.
+func NewCustomComponentValue() resource.Resource {
+	return &customComponentValue{}
+}
+
+// customComponentValue is the resource implementation.
+type customComponentValue struct {
+	client *azurenamingtool.Client
+}
+
+// customComponentValueModel maps the resource schema data.
+type customComponentValueModel struct {
+	ID        types.Int64  `tfsdk:"id"`
+	Component types.String `tfsdk:"component"`
+	Name      types.String `tfsdk:"name"`
+	ShortName types.String `tfsdk:"short_name"`
+	SortOrder types.Int64  `tfsdk:"sort_order"`
+}
+
+// customComponentValueItem is an allowed value of a custom component as exchanged with the
+// Azure Naming Tool. The parent component is identified by its key, see customComponentKey.
+type customComponentValueItem struct {
+	ID              int64  `json:"id"`
+	ParentComponent string `json:"parentComponent"`
+	Name            string `json:"name"`
+	ShortName       string `json:"shortName"`
+	SortOrder       int64  `json:"sortOrder"`
+}
+
+// Metadata returns the resource type name.
+func (r *customComponentValue) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_component_value"
+}
+
+// Schema defines the schema for the resource.
+func (r *customComponentValue) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an allowed value of a custom name component of the Azure Naming Tool configuration.",
+		MarkdownDescription: "Manages an allowed value of a custom name component of the Azure Naming Tool configuration.\n\n" +
+			"Changes to the configuration require the `admin_password` provider setting. " +
+			"Names generated before a change keep the short name they were generated with.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description:   "The unique identifier of the value in the Azure Naming Tool.",
+				Computed:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"component": schema.StringAttribute{
+				Description: "Name of the custom component the value belongs to, usually the name attribute of a " +
+					"proactnaming_custom_component resource. Changing the component forces a new value.",
+				MarkdownDescription: "Name of the custom component the value belongs to, usually the `name` attribute of a " +
+					"`proactnaming_custom_component` resource. Changing the component forces a new value.",
+				Required:      true,
+				Validators:    []validator.String{StringNotEmpty()},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				Description: "Display name of the value.",
+				Required:    true,
+				Validators:  []validator.String{StringNotEmpty()},
+			},
+			"short_name": schema.StringAttribute{
+				Description: "Short name of the value used in generated names (e.g., 'web'). Must be unique within the component, " +
+					"ignoring case, and respect the length limits of the component.",
+				MarkdownDescription: "Short name of the value used in generated names (e.g., `web`). Must be unique within the component, " +
+					"ignoring case, and respect the length limits of the component.",
+				Required:   true,
+				Validators: []validator.String{StringNotEmpty()},
+			},
+			"sort_order": schema.Int64Attribute{
+				Description:   "Position of the value in the Azure Naming Tool. Defaults to the end of the list.",
+				Optional:      true,
+				Computed:      true,
+				Validators:    []validator.Int64{Int64AtLeast(0)},
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *customComponentValue) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan customComponentValueModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	item, err := createCustomComponentValue(r.client, plan.Component.ValueString(), plan.item())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Custom Component Value",
+			fmt.Sprintf("An error occurred while creating the value %q of the custom component %q: %s",
+				plan.ShortName.ValueString(), plan.Component.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	plan.setItem(item)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *customComponentValue) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state customComponentValueModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, err := listCustomComponentValues(readOnlyClient(r.client), "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Custom Component Value",
+			fmt.Sprintf("An error occurred while reading custom component value %d: %s", state.ID.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	// Values removed outside of Terraform are recreated on the next apply.
+	var found *customComponentValueItem
+	for i := range items {
+		if items[i].ID == state.ID.ValueInt64() {
+			found = &items[i]
+			break
+		}
+	}
+	if found == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The tool only knows the key of the component, so the configured spelling is kept
+	// unless the value was moved to another component or the state was imported.
+	if state.Component.IsNull() || customComponentKey(state.Component.ValueString()) != found.ParentComponent {
+		state.Component = types.StringValue(found.ParentComponent)
+	}
+	state.setItem(*found)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *customComponentValue) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state customComponentValueModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	plan.ID = state.ID
+	if plan.SortOrder.IsUnknown() {
+		plan.SortOrder = state.SortOrder
+	}
+
+	if err := doNamingToolRequest(r.client, http.MethodPost, customComponentValuesPath, plan.item(), nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Custom Component Value",
+			fmt.Sprintf("An error occurred while updating custom component value %d: %s", plan.ID.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *customComponentValue) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state customComponentValueModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	err := doNamingToolRequest(r.client, http.MethodDelete, fmt.Sprintf("%s/%d", customComponentValuesPath, state.ID.ValueInt64()), nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Custom Component Value",
+			fmt.Sprintf("An error occurred while deleting custom component value %d: %s", state.ID.ValueInt64(), err.Error()),
+		)
+	}
+}
+
+// ModifyPlan fails the plan when the planned short name is already used within the component by
+// another resource of the configuration or by another value, or when it does not respect the
+// length limits of an existing component.
+func (r *customComponentValue) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Skip for destroy operations.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan customComponentValueModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || plan.Component.IsUnknown() || plan.ShortName.IsUnknown() {
+		return
+	}
+
+	component, shortName := plan.Component.ValueString(), plan.ShortName.ValueString()
+	owner := fmt.Sprintf("proactnaming_custom_component_value (component=%q, short_name=%q)", component, shortName)
+	key := "custom_component_value:" + customComponentKey(component) + ":" + strings.ToLower(shortName)
+	others, diags := plannedNames.registerPlanned(ctx, req, resp, key, owner)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(others) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("short_name"),
+			"Duplicate Short Name",
+			fmt.Sprintf("The short name %q is planned by %d other resource(s) in this configuration:\n\n- %s\n\n"+
+				"Short names must be unique within the custom component %q.", shortName, len(others), strings.Join(others, "\n- "), component),
+		)
+		return
+	}
+
+	// Skip if the client is not available (shouldn't happen, but safety check).
+	if r.client == nil {
+		return
+	}
+
+	// Components created in the same apply are checked when the value is created.
+	components, err := listCustomComponents(readOnlyClient(r.client))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Custom Components",
+			fmt.Sprintf("An error occurred while checking the value %q of the custom component %q: %s", shortName, component, err.Error()),
+		)
+		return
+	}
+	definition := findCustomComponent(components, component)
+	if definition == nil {
+		return
+	}
+	checkCustomComponentLength(&resp.Diagnostics, path.Root("short_name"), *definition, shortName)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state customComponentValueModel
+	if !req.State.Raw.IsNull() {
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() || strings.EqualFold(state.ShortName.ValueString(), shortName) {
+			return
+		}
+	}
+
+	values, err := listCustomComponentValues(readOnlyClient(r.client), component)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Custom Component Values",
+			fmt.Sprintf("An error occurred while checking that the short name %q is unique: %s", shortName, err.Error()),
+		)
+		return
+	}
+	for _, value := range values {
+		if strings.EqualFold(value.ShortName, shortName) && value.ID != state.ID.ValueInt64() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("short_name"),
+				"Duplicate Short Name",
+				fmt.Sprintf("The short name %q is already used by the value %q of the custom component %q with ID %d. "+
+					"Choose another short name or import the existing value.", shortName, value.Name, component, value.ID),
+			)
+			return
+		}
+	}
+}
+
+// ImportState imports a value by its ID in the Azure Naming Tool.
+func (r *customComponentValue) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importInt64ID(ctx, req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *customComponentValue) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*azurenamingtool.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *azurenamingtool.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// item returns the value described by the model. Unknown sort orders are sent as -1.
+func (m customComponentValueModel) item() customComponentValueItem {
+	sortOrder := int64(-1)
+	if !m.SortOrder.IsNull() && !m.SortOrder.IsUnknown() {
+		sortOrder = m.SortOrder.ValueInt64()
+	}
+	return customComponentValueItem{
+		ID:              m.ID.ValueInt64(),
+		ParentComponent: customComponentKey(m.Component.ValueString()),
+		Name:            m.Name.ValueString(),
+		ShortName:       m.ShortName.ValueString(),
+		SortOrder:       sortOrder,
+	}
+}
+
+// setItem updates the model from a value, except for its component.
+func (m *customComponentValueModel) setItem(item customComponentValueItem) {
+	m.ID = types.Int64Value(item.ID)
+	m.Name = types.StringValue(item.Name)
+	m.ShortName = types.StringValue(item.ShortName)
+	m.SortOrder = types.Int64Value(item.SortOrder)
+}
+
+// listCustomComponentValues returns the values of the named custom component, or the values of
+// all custom components when component is empty.
+func listCustomComponentValues(client *azurenamingtool.Client, component string) ([]customComponentValueItem, error) {
+	var items []customComponentValueItem
+	if err := doNamingToolRequest(client, http.MethodGet, customComponentValuesPath, nil, &items); err != nil {
+		return nil, err
+	}
+	if component == "" {
+		return items, nil
+	}
+
+	key := customComponentKey(component)
+	values := make([]customComponentValueItem, 0, len(items))
+	for _, item := range items {
+		if item.ParentComponent == key {
+			values = append(values, item)
+		}
+	}
+	return values, nil
+}
+
+// createCustomComponentValue creates a value of the named custom component and returns it with
+// the ID assigned by the tool. The component must exist and the short name must respect its length
+// limits. Values without a sort order are appended to the end of the values of the component.
+// The tool does not return the new value, so it is looked up by its short name afterwards.
+func createCustomComponentValue(client *azurenamingtool.Client, component string, item customComponentValueItem) (customComponentValueItem, error) {
+	components, err := listCustomComponents(client)
+	if err != nil {
+		return item, err
+	}
+	definition := findCustomComponent(components, component)
+	if definition == nil {
+		return item, fmt.Errorf("the custom component %q does not exist", component)
+	}
+	if minLength, maxLength := definition.lengthLimits(); len(item.ShortName) < minLength || len(item.ShortName) > maxLength {
+		return item, fmt.Errorf("the short name must be %d to %d characters long, got %d", minLength, maxLength, len(item.ShortName))
+	}
+
+	values, err := listCustomComponentValues(client, component)
+	if err != nil {
+		return item, err
+	}
+	for _, existing := range values {
+		if strings.EqualFold(existing.ShortName, item.ShortName) {
+			return item, fmt.Errorf("a value with short name %q already exists with ID %d, import it instead", existing.ShortName, existing.ID)
+		}
+	}
+
+	if item.SortOrder < 0 {
+		item.SortOrder = 0
+		for _, existing := range values {
+			item.SortOrder = max(item.SortOrder, existing.SortOrder+1)
+		}
+	}
+
+	item.ID = 0
+	item.ParentComponent = customComponentKey(component)
+	if err := doNamingToolRequest(client, http.MethodPost, customComponentValuesPath, item, nil); err != nil {
+		return item, err
+	}
+
+	values, err = listCustomComponentValues(client, component)
+	if err != nil {
+		return item, err
+	}
+	for _, created := range values {
+		if strings.EqualFold(created.ShortName, item.ShortName) {
+			return created, nil
+		}
+	}
+
+	return item, fmt.Errorf("the value %q was not found after creating it", item.ShortName)
+}
+
+// checkCustomComponentLength adds an error diagnostic for the attribute when value does not
+// respect the length limits of the custom component.
+func checkCustomComponentLength(diags *diag.Diagnostics, attribute path.Path, definition customComponentItem, value string) {
+	minLength, maxLength := definition.lengthLimits()
+	if len(value) >= minLength && len(value) <= maxLength {
+		return
+	}
+
+	diags.AddAttributeError(
+		attribute,
+		"Invalid Custom Component Value",
+		fmt.Sprintf("The value %q of the custom component %q must be %d to %d characters long, got %d.",
+			value, definition.DisplayName, minLength, maxLength, len(value)),
+	)
+}
+
+// checkCustomComponentValue adds an error diagnostic for the attribute when the custom component
+// with the given name is defined in the Azure Naming Tool and value does not respect its length
+// limits or is not one of its allowed values. Components without values only limit the length.
+func checkCustomComponentValue(diags *diag.Diagnostics, client *azurenamingtool.Client, attribute path.Path, component, value string) {
+	components, err := listCustomComponents(client)
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Custom Components",
+			fmt.Sprintf("An error occurred while validating the %s component: %s", component, err.Error()),
+		)
+		return
+	}
+	definition := findCustomComponent(components, component)
+	if definition == nil {
+		return
+	}
+
+	checkCustomComponentLength(diags, attribute, *definition, value)
+	if diags.HasError() {
+		return
+	}
+
+	values, err := listCustomComponentValues(client, component)
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Custom Component Values",
+			fmt.Sprintf("An error occurred while validating the %s component: %s", component, err.Error()),
+		)
+		return
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	allowed := make([]string, 0, len(values))
+	for _, item := range values {
+		if strings.EqualFold(item.ShortName, value) {
+			return
+		}
+		allowed = append(allowed, item.ShortName)
+	}
+
+	diags.AddAttributeError(
+		attribute,
+		"Invalid Custom Component Value",
+		fmt.Sprintf("The value %q is not an allowed value of the custom component %q. Allowed values: %s.",
+			value, definition.DisplayName, strings.Join(allowed, ", ")),
+	)
+}
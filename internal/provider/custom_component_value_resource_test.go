@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCustomComponentValueLifecycle(t *testing.T) {
+	_, client := newFakeNamingTool(t)
+
+	// Values of unknown components are rejected.
+	if _, err := createCustomComponentValue(client, "Application", customComponentValueItem{Name: "Web", ShortName: "web", SortOrder: -1}); err == nil {
+		t.Error("expected an error for an unknown component")
+	}
+
+	if _, err := createCustomComponent(client, customComponentItem{DisplayName: "Application", SortOrder: -1, MinLength: "2", MaxLength: "4"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	first, err := createCustomComponentValue(client, "Application", customComponentValueItem{Name: "Web", ShortName: "web", SortOrder: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if first.ID == 0 || first.ParentComponent != "application" || first.SortOrder != 3 {
+		t.Fatalf("expected the created value to have an ID, parent and sort order 3, got %+v", first)
+	}
+
+	// Values without a sort order are appended.
+	second, err := createCustomComponentValue(client, "application", customComponentValueItem{Name: "API", ShortName: "api", SortOrder: -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if second.SortOrder != 4 {
+		t.Errorf("expected sort order 4, got %d", second.SortOrder)
+	}
+
+	// Short names are unique within the component and respect its length limits.
+	if _, err := createCustomComponentValue(client, "Application", customComponentValueItem{Name: "Again", ShortName: "WEB", SortOrder: -1}); err == nil {
+		t.Error("expected an error for a duplicate short name")
+	}
+	if _, err := createCustomComponentValue(client, "Application", customComponentValueItem{Name: "Portal", ShortName: "portal", SortOrder: -1}); err == nil {
+		t.Error("expected an error for a short name exceeding the maximum length")
+	}
+
+	if _, err := createCustomComponent(client, customComponentItem{DisplayName: "Workload", SortOrder: -1, MinLength: "1", MaxLength: "10"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := createCustomComponentValue(client, "Workload", customComponentValueItem{Name: "Web", ShortName: "web", SortOrder: -1}); err != nil {
+		t.Errorf("expected short names to be unique per component only, got %s", err)
+	}
+
+	values, err := listCustomComponentValues(client, "Application")
+	if err != nil || len(values) != 2 {
+		t.Errorf("expected two values of the Application component, got %+v: %v", values, err)
+	}
+	values, err = listCustomComponentValues(client, "")
+	if err != nil || len(values) != 3 {
+		t.Errorf("expected three values in total, got %+v: %v", values, err)
+	}
+}
+
+func TestCheckCustomComponentValue(t *testing.T) {
+	_, client := newFakeNamingTool(t)
+
+	check := func(value string) diag.Diagnostics {
+		var diags diag.Diagnostics
+		checkCustomComponentValue(&diags, client, path.Root("application"), "Application", value)
+		return diags
+	}
+
+	// Without a definition any value is accepted.
+	if diags := check("anything"); diags.HasError() {
+		t.Errorf("unexpected diagnostics without a definition: %v", diags)
+	}
+
+	if _, err := createCustomComponent(client, customComponentItem{DisplayName: "Application", SortOrder: -1, MinLength: "2", MaxLength: "4"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Without values only the length is limited.
+	if diags := check("abc"); diags.HasError() {
+		t.Errorf("unexpected diagnostics for a value within the limits: %v", diags)
+	}
+	if diags := check("toolong"); !diags.HasError() {
+		t.Error("expected an error for a value exceeding the maximum length")
+	}
+
+	if _, err := createCustomComponentValue(client, "Application", customComponentValueItem{Name: "Web", ShortName: "web", SortOrder: -1}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diags := check("WEB"); diags.HasError() {
+		t.Errorf("unexpected diagnostics for an allowed value: %v", diags)
+	}
+	if diags := check("api"); !diags.HasError() {
+		t.Error("expected an error for a value that is not allowed")
+	}
+}
+
+func TestCustomComponentValueModifyPlanDuplicates(t *testing.T) {
+	resetPlannedNames(t)
+	r := &customComponentValue{}
+
+	state := customComponentValueModel{
+		ID:        types.Int64Value(1),
+		Component: types.StringValue("Business Unit"),
+		Name:      types.StringValue("Finance"),
+		ShortName: types.StringValue("fin"),
+		SortOrder: types.Int64Value(1),
+	}
+	config := state
+	config.ID = types.Int64Null()
+	config.Component = types.StringValue("Cost Center")
+	plan := config
+	plan.ID = types.Int64Unknown()
+
+	// Moving the value to another component plans the replacement twice without clashing with itself.
+	if _, diags := replacePlan(t, r, &state, &config, &plan); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// A second value with the same short name in the component is rejected.
+	config.ShortName, plan.ShortName = types.StringValue("FIN"), types.StringValue("FIN")
+	if _, diags := modifyPlan(t, r, nil, &config, &plan); !diags.HasError() {
+		t.Error("expected an error for a second value with the same short name")
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// generateNameIdentityModel maps the identity schema data.
+type generateNameIdentityModel struct {
+	ID types.Int64 `tfsdk:"id"`
+}
+
+// IdentitySchema defines the identity schema for the resource.
+func (r *generateName) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.Int64Attribute{
+				Description:       "The unique identifier for the generated name in the Azure Naming Tool.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// ImportState imports a generated name by the ID of its entry in the Azure Naming Tool, given
+// either as the import ID or as the id identity attribute. Read fills in the components.
+func (r *generateName) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var id types.Int64
+
+	if req.ID != "" {
+		value, err := strconv.ParseInt(req.ID, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Import ID",
+				fmt.Sprintf("The import ID must be the numeric ID of the generated name in the Azure Naming Tool, got %q.", req.ID),
+			)
+			return
+		}
+		id = types.Int64Value(value)
+	} else {
+		diags := req.Identity.GetAttribute(ctx, path.Root("id"), &id)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	diags := resp.State.SetAttribute(ctx, path.Root("id"), id)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setGenerateNameIdentity(ctx, resp.Identity, id)...)
+}
+
+// readImported fills in an imported state from the entry of the generated names log with the
+// ID of the state. It reports false when there is no such entry.
+func (r *generateName) readImported(state *generateNameModel) (bool, error) {
+	entries, err := getGeneratedNamesLog(readOnlyClient(r.client))
+	if err != nil {
+		return false, err
+	}
+
+	for _, entry := range entries {
+		if entry.ID != state.ID.ValueInt64() {
+			continue
+		}
+
+		state.Organization = types.StringValue(entry.component("organization"))
+		state.ResourceType = types.StringValue(entry.component("type"))
+		state.Application = types.StringValue(entry.component("application"))
+		state.Function = optionalString(entry.component("function"))
+		state.Instance = types.StringValue(entry.component("instance"))
+		state.Location = types.StringValue(entry.component("location"))
+		state.Environment = types.StringValue(entry.component("environment"))
+		state.Truncation = types.StringNull()
+		state.TruncationPriority = types.ListNull(types.StringType)
+		state.Keepers = types.MapNull(types.StringType)
+		state.LockName = types.BoolNull()
+		state.RetainOnDestroy = types.BoolNull()
+		state.DeletionProtection = types.BoolNull()
+		state.ResourceName = types.StringValue(entry.ResourceName)
+		state.Success = types.BoolValue(true)
+		state.Message = types.StringValue("")
+		state.TruncationApplied = types.StringValue(truncationNone)
+		state.OriginalName = types.StringValue(entry.ResourceName)
+
+		// The entry is in use, so it must never be adopted as an orphan.
+		orphans.claim(entry.ID)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// setGenerateNameIdentity sets the identity of a generated name when Terraform supports identities.
+func setGenerateNameIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id types.Int64) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, generateNameIdentityModel{ID: id})
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+func TestGenerateNameImportState(t *testing.T) {
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("unable to create provider server: %s", err)
+	}
+
+	identityType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.Number}}
+	identity, err := tfprotov6.NewDynamicValue(identityType, tftypes.NewValue(identityType, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.Number, 7),
+	}))
+	if err != nil {
+		t.Fatalf("unable to create identity: %s", err)
+	}
+
+	tests := map[string]*tfprotov6.ImportResourceStateRequest{
+		"id": {
+			TypeName: "proactnaming_generate_name",
+			ID:       "7",
+		},
+		"identity": {
+			TypeName: "proactnaming_generate_name",
+			Identity: &tfprotov6.ResourceIdentityData{IdentityData: &identity},
+		},
+	}
+
+	for name, req := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, err := server.ImportResourceState(ctx, req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+			if len(resp.ImportedResources) != 1 {
+				t.Fatalf("expected one imported resource, got %d", len(resp.ImportedResources))
+			}
+
+			imported := resp.ImportedResources[0]
+			if imported.Identity == nil {
+				t.Fatal("expected the imported resource to have an identity")
+			}
+			value, err := imported.Identity.IdentityData.Unmarshal(identityType)
+			if err != nil {
+				t.Fatalf("unable to read identity: %s", err)
+			}
+
+			var attributes map[string]tftypes.Value
+			var id big.Float
+			if err := value.As(&attributes); err != nil {
+				t.Fatalf("unable to read identity: %s", err)
+			}
+			if err := attributes["id"].As(&id); err != nil || id.Cmp(big.NewFloat(7)) != 0 {
+				t.Errorf("expected identity id 7, got %s", attributes["id"])
+			}
+		})
+	}
+
+	resp, err := server.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: "proactnaming_generate_name",
+		ID:       "rg-man-webapp",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(resp.Diagnostics) == 0 {
+		t.Error("expected an error for a non-numeric import ID")
+	}
+}
+
+func TestGenerateNameReadImported(t *testing.T) {
+	_, client := newFakeNamingTool(t)
+	r := &generateName{client: client}
+
+	issued, err := client.GenerateName(azurenamingtool.GenerateNameRequest{
+		ResourceOrg:         "man",
+		ResourceType:        "rg",
+		ResourceFunction:    "app",
+		ResourceInstance:    "001",
+		ResourceLocation:    "euw",
+		ResourceEnvironment: "dev",
+		CustomComponents: azurenamingtool.GenerateNameRequestCustomComponents{
+			Application: "webapp",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	state := generateNameModel{ID: types.Int64Value(issued.ResourceNameDetails.ID)}
+	found, err := r.readImported(&state)
+	if err != nil || !found {
+		t.Fatalf("expected the entry to be found, got %t: %v", found, err)
+	}
+	if state.ResourceName.ValueString() != issued.ResourceName || state.Instance.ValueString() != "001" ||
+		state.Organization.ValueString() != "man" || state.Function.ValueString() != "app" {
+		t.Errorf("unexpected imported state: %+v", state)
+	}
+
+	missing := generateNameModel{ID: types.Int64Value(issued.ResourceNameDetails.ID + 100)}
+	if found, err := r.readImported(&missing); err != nil || found {
+		t.Errorf("expected an unknown ID not to be found, got %t: %v", found, err)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+func TestCheckNameRemoval(t *testing.T) {
+	tests := map[string]struct {
+		retain, protect bool
+		errors          int
+		warnings        int
+	}{
+		"unset":     {},
+		"retained":  {retain: true, warnings: 1},
+		"protected": {protect: true, warnings: 1},
+		"both":      {retain: true, protect: true, warnings: 1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			state := generateNameModel{
+				ResourceName:       types.StringValue("rg-man-webapp-001"),
+				RetainOnDestroy:    types.BoolValue(test.retain),
+				DeletionProtection: types.BoolValue(test.protect),
+			}
+
+			var diags diag.Diagnostics
+			checkNameRemoval(&diags, state, "destroy")
+
+			if got := diags.ErrorsCount(); got != test.errors {
+				t.Errorf("expected %d errors, got %d: %v", test.errors, got, diags)
+			}
+			if got := diags.WarningsCount(); got != test.warnings {
+				t.Errorf("expected %d warnings, got %d: %v", test.warnings, got, diags)
+			}
+		})
+	}
+}
+
+func TestGenerateNameModelReplaces(t *testing.T) {
+	state := generateNameModel{
+		Organization:       types.StringValue("man"),
+		ResourceType:       types.StringValue("rg"),
+		Application:        types.StringValue("webapp"),
+		Function:           types.StringValue("app"),
+		Instance:           types.StringValue("001"),
+		Location:           types.StringValue("euw"),
+		Environment:        types.StringValue("dev"),
+		Truncation:         types.StringNull(),
+		TruncationPriority: types.ListNull(types.StringType),
+		Keepers:            types.MapNull(types.StringType),
+		LockName:           types.BoolNull(),
+		RetainOnDestroy:    types.BoolNull(),
+		DeletionProtection: types.BoolNull(),
+	}
+
+	plan := state
+	plan.RetainOnDestroy = types.BoolValue(true)
+	plan.DeletionProtection = types.BoolValue(true)
+	if plan.replaces(state) {
+		t.Error("changing lifecycle settings must not replace the resource")
+	}
+
+	plan.Environment = types.StringValue(" Dev ")
+	if plan.replaces(state) {
+		t.Error("changing only the case or whitespace of a component must not replace the resource")
+	}
+
+	plan.Keepers = types.MapValueMust(types.StringType, map[string]attr.Value{"generation": types.StringValue("2")})
+	if !plan.replaces(state) {
+		t.Error("changing the keepers must replace the resource")
+	}
+
+	plan.Keepers = state.Keepers
+	plan.Instance = types.StringValue("002")
+	if !plan.replaces(state) {
+		t.Error("changing the instance must replace the resource")
+	}
+
+	plan.LockName = types.BoolValue(true)
+	if plan.replaces(state) {
+		t.Error("changing the instance of a locked name must not replace the resource")
+	}
+
+	plan.Keepers = types.MapValueMust(types.StringType, map[string]attr.Value{"generation": types.StringValue("2")})
+	if !plan.replaces(state) {
+		t.Error("changing the keepers of a locked name must replace the resource")
+	}
+}
+
+func TestGenerateNameDeleteProtected(t *testing.T) {
+	ctx := context.Background()
+	tool, client := newFakeNamingTool(t)
+	r := &generateName{client: client}
+
+	generateResponse, err := client.GenerateName(azurenamingtool.GenerateNameRequest{ResourceOrg: "man", ResourceType: "rg", ResourceInstance: "001"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := generateNameModel{
+		Organization:       types.StringValue("man"),
+		ResourceType:       types.StringValue("rg"),
+		Instance:           types.StringValue("001"),
+		TruncationPriority: types.ListNull(types.StringType),
+		Keepers:            types.MapNull(types.StringType),
+		DeletionProtection: types.BoolValue(true),
+		ID:                 types.Int64Value(generateResponse.ResourceNameDetails.ID),
+		ResourceName:       types.StringValue(generateResponse.ResourceName),
+	}
+
+	// The plan only warns, so the protected name is rejected during apply.
+	resp := resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: tfsdk.State{Schema: schemaResp.Schema, Raw: newPlan(t, schemaResp.Schema, &state).Raw}}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error when deleting a protected name")
+	}
+	if tool.count() != 1 {
+		t.Errorf("expected the protected name to be kept, got %d names", tool.count())
+	}
+}
+
+func TestGenerateNameNormalizesComponents(t *testing.T) {
+	ctx := context.Background()
+	_, client := newFakeNamingTool(t)
+	r := &generateName{client: client}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	state := generateNameModel{
+		Organization:       types.StringValue("MAN"),
+		ResourceType:       types.StringValue("rg"),
+		Instance:           types.StringValue("001"),
+		Environment:        types.StringValue(" Dev "),
+		TruncationPriority: types.ListNull(types.StringType),
+		Keepers:            types.MapNull(types.StringType),
+		ID:                 types.Int64Value(1),
+		ResourceName:       types.StringValue("man-rg-001-dev"),
+	}
+
+	// The refresh stores the components as the tool normalises them.
+	req := resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: newPlan(t, s, &state).Raw}}
+	initPrivateState(&req.Private)
+	resp := resource.ReadResponse{State: req.State}
+	r.Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	var refreshed generateNameModel
+	resp.State.Get(ctx, &refreshed)
+	if refreshed.Organization.ValueString() != "man" || refreshed.Environment.ValueString() != "dev" || !refreshed.Function.IsNull() {
+		t.Errorf("expected normalised components, got organization %s, environment %s and function %s",
+			refreshed.Organization, refreshed.Environment, refreshed.Function)
+	}
+
+	// Equivalent configured values plan the stored value, other values are planned as configured.
+	for value, expected := range map[string]string{"Dev": "dev", " DEV ": "dev", "prd": "prd"} {
+		modifyResp := planmodifier.StringResponse{PlanValue: types.StringValue(value)}
+		useStateForEquivalentComponent().PlanModifyString(ctx, planmodifier.StringRequest{
+			StateValue: types.StringValue("dev"),
+			PlanValue:  types.StringValue(value),
+		}, &modifyResp)
+		if modifyResp.PlanValue.ValueString() != expected {
+			t.Errorf("expected %q to plan %q, got %s", value, expected, modifyResp.PlanValue)
+		}
+	}
+}
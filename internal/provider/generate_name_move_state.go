@@ -0,0 +1,281 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// movedFromKey is the private state key marking a state moved from another resource type whose
+// name is not registered in the Azure Naming Tool yet. The components of the first plan after
+// the move are adopted in place and the existing name is registered during that apply.
+const movedFromKey = "moved_from"
+
+// azurecafNameType is the type name of the azurecaf_name resource of the aztfmod/azurecaf provider.
+const azurecafNameType = "azurecaf_name"
+
+// azurecafSlugs maps azurerm resource types to the abbreviations used by azurecaf and the
+// default Azure Naming Tool configuration. Unknown types are kept without the azurerm_ prefix.
+var azurecafSlugs = map[string]string{
+	"azurerm_resource_group":          "rg",
+	"azurerm_storage_account":         "st",
+	"azurerm_key_vault":               "kv",
+	"azurerm_virtual_network":         "vnet",
+	"azurerm_subnet":                  "snet",
+	"azurerm_network_security_group":  "nsg",
+	"azurerm_network_interface":       "nic",
+	"azurerm_public_ip":               "pip",
+	"azurerm_virtual_machine":         "vm",
+	"azurerm_linux_virtual_machine":   "vm",
+	"azurerm_windows_virtual_machine": "vm",
+	"azurerm_kubernetes_cluster":      "aks",
+	"azurerm_container_registry":      "cr",
+	"azurerm_app_service_plan":        "asp",
+	"azurerm_service_plan":            "asp",
+	"azurerm_app_service":             "app",
+	"azurerm_linux_web_app":           "app",
+	"azurerm_windows_web_app":         "app",
+	"azurerm_function_app":            "func",
+	"azurerm_linux_function_app":      "func",
+	"azurerm_windows_function_app":    "func",
+	"azurerm_log_analytics_workspace": "log",
+	"azurerm_application_insights":    "appi",
+	"azurerm_mssql_server":            "sql",
+	"azurerm_sql_server":              "sql",
+	"azurerm_mssql_database":          "sqldb",
+	"azurerm_cosmosdb_account":        "cosmos",
+	"azurerm_user_assigned_identity":  "id",
+}
+
+// azurecafNameModel maps the attributes of azurecaf_name used when moving its state.
+type azurecafNameModel struct {
+	Name         types.String `tfsdk:"name"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	Prefixes     types.List   `tfsdk:"prefixes"`
+	Suffixes     types.List   `tfsdk:"suffixes"`
+	Separator    types.String `tfsdk:"separator"`
+	Result       types.String `tfsdk:"result"`
+}
+
+// azurecafNameSchema returns the subset of the azurecaf_name schema read when moving its state.
+func azurecafNameSchema() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name":          schema.StringAttribute{Optional: true},
+			"resource_type": schema.StringAttribute{Optional: true},
+			"prefixes":      schema.ListAttribute{ElementType: types.StringType, Optional: true},
+			"suffixes":      schema.ListAttribute{ElementType: types.StringType, Optional: true},
+			"separator":     schema.StringAttribute{Optional: true},
+			"result":        schema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+// MoveState returns the state movers accepting states of other resource types.
+func (r *generateName) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			SourceSchema: azurecafNameSchema(),
+			StateMover:   r.moveStateFromAzurecafName,
+		},
+	}
+}
+
+// moveStateFromAzurecafName moves an azurecaf_name state. The components are derived from
+// the azurecaf inputs on a best-effort basis: name becomes the application, the prefixes the
+// organization and the suffixes the environment and location. The configured components replace
+// them in place during the first apply, which registers the existing name in the Azure Naming Tool.
+func (r *generateName) moveStateFromAzurecafName(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != azurecafNameType || !strings.HasSuffix(req.SourceProviderAddress, "aztfmod/azurecaf") {
+		return
+	}
+
+	if req.SourceState == nil {
+		resp.Diagnostics.AddError(
+			"Unable to Move azurecaf_name State",
+			"The source state could not be read using the expected azurecaf_name schema. "+
+				"Check that the source resource was created by a supported version of the aztfmod/azurecaf provider.",
+		)
+		return
+	}
+
+	var source azurecafNameModel
+	diags := req.SourceState.Get(ctx, &source)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if source.Result.ValueString() == "" {
+		resp.Diagnostics.AddError(
+			"Unable to Move azurecaf_name State",
+			"The azurecaf_name state has no result, so there is no existing name to keep. "+
+				"Remove the moved block and create the proactnaming_generate_name resource instead.",
+		)
+		return
+	}
+
+	var prefixes, suffixes []string
+	resp.Diagnostics.Append(source.Prefixes.ElementsAs(ctx, &prefixes, false)...)
+	resp.Diagnostics.Append(source.Suffixes.ElementsAs(ctx, &suffixes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	separator := source.Separator.ValueString()
+	if source.Separator.IsNull() {
+		separator = "-"
+	}
+
+	state := generateNameModel{
+		Organization:       optionalString(strings.Join(prefixes, separator)),
+		ResourceType:       optionalString(azurecafSlug(source.ResourceType.ValueString())),
+		Application:        source.Name,
+		Function:           types.StringNull(),
+		Instance:           types.StringNull(),
+		Location:           types.StringNull(),
+		Environment:        types.StringNull(),
+		Truncation:         types.StringNull(),
+		TruncationPriority: types.ListNull(types.StringType),
+		Keepers:            types.MapNull(types.StringType),
+		LockName:           types.BoolNull(),
+		RetainOnDestroy:    types.BoolNull(),
+		DeletionProtection: types.BoolNull(),
+		ID:                 types.Int64Null(),
+		ResourceName:       source.Result,
+		Success:            types.BoolValue(true),
+		Message:            types.StringValue(fmt.Sprintf("Moved from %s.", azurecafNameType)),
+		TruncationApplied:  types.StringValue(truncationNone),
+		OriginalName:       source.Result,
+	}
+	if len(suffixes) > 0 {
+		state.Environment = types.StringValue(suffixes[0])
+	}
+	if len(suffixes) > 1 {
+		state.Location = types.StringValue(suffixes[1])
+	}
+
+	diags = resp.TargetState.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.TargetPrivate.SetKey(ctx, movedFromKey, []byte(fmt.Sprintf(`{"source":%q}`, azurecafNameType)))
+	resp.Diagnostics.Append(diags...)
+}
+
+// checkMovedName adds an error diagnostic when the configured components of a moved state do not
+// reproduce its existing name. The name is rendered from the naming convention of the Azure Naming
+// Tool; names that need truncation, and components only known during apply, are checked by
+// registerMovedName instead.
+func (r *generateName) checkMovedName(ctx context.Context, diags *diag.Diagnostics, config tfsdk.Config, plan generateNameModel) {
+	if plan.Instance.IsUnknown() {
+		var configInstance types.String
+		diags.Append(config.GetAttribute(ctx, path.Root("instance"), &configInstance)...)
+		if configInstance.IsNull() {
+			diags.AddAttributeError(
+				path.Root("instance"),
+				"Instance Required for Moved Name",
+				fmt.Sprintf("The instance of a moved name cannot be allocated, as the existing name %q already has one. "+
+					"Set instance to the instance used in the existing name.", plan.ResourceName.ValueString()),
+			)
+		}
+		return
+	}
+	if !plan.componentsKnown() || r.client == nil {
+		return
+	}
+
+	request := newGenerateNameRequest(plan)
+	maxLength, applyDelimiter, err := resourceTypeLimits(readOnlyClient(r.client), request.ResourceType)
+	if err != nil {
+		diags.AddError("Unable to Check Moved Name", fmt.Sprintf("An error occurred while reading resource types: %s", err.Error()))
+		return
+	}
+	name, err := requestedName(readOnlyClient(r.client), request, applyDelimiter)
+	if err != nil {
+		diags.AddError("Unable to Check Moved Name", fmt.Sprintf("An error occurred while reading the naming convention: %s", err.Error()))
+		return
+	}
+
+	existing := plan.ResourceName.ValueString()
+	if (maxLength > 0 && len(name) > maxLength) || strings.EqualFold(name, existing) {
+		return
+	}
+
+	diags.AddError(
+		"Moved Name Does Not Match Components",
+		fmt.Sprintf("The configured components generate the name %q, but the moved resource uses %q. "+
+			"Adjust the components so that they reproduce the existing name.", name, existing),
+	)
+}
+
+// registerMovedName registers the name of a moved state in the Azure Naming Tool using the
+// configured components. When the components do not reproduce the existing name, the new entry
+// is removed again and an error is returned, also when lock_name is set, so that the tool never
+// holds a name other than the one in use.
+func (r *generateName) registerMovedName(ctx context.Context, diags *diag.Diagnostics, plan *generateNameModel) {
+	existing := plan.ResourceName.ValueString()
+
+	model := *plan
+	generateResponse, err := r.generate(ctx, &model, nil)
+	if err != nil {
+		addGenerateNameError(diags, "Unable to Register Moved Name", err)
+		return
+	}
+
+	id := generateResponse.ResourceNameDetails.ID
+	if strings.EqualFold(generateResponse.ResourceName, existing) {
+		plan.ID = types.Int64Value(id)
+		return
+	}
+
+	// Remove the entry so that it does not reserve a name that is not in use.
+	if id != 0 {
+		_, _ = r.client.DeleteName(azurenamingtool.DeleteGeneratedNameRequest{ID: id})
+	}
+
+	diags.AddError(
+		"Moved Name Does Not Match Components",
+		fmt.Sprintf("The configured components generate the name %q, but the moved resource uses %q. "+
+			"Adjust the components so that they reproduce the existing name.", generateResponse.ResourceName, existing),
+	)
+}
+
+// azurecafSlug returns the Azure Naming Tool resource type for an azurecaf resource type.
+func azurecafSlug(resourceType string) string {
+	if slug, ok := azurecafSlugs[resourceType]; ok {
+		return slug
+	}
+	return strings.TrimPrefix(resourceType, "azurerm_")
+}
+
+// optionalString returns a null string for empty values.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// privateStateReader is implemented by the private state data of the framework requests.
+type privateStateReader interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// movedState reports whether the private state marks a moved state whose name is not registered yet.
+func movedState(ctx context.Context, private privateStateReader) (bool, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, movedFromKey)
+	return len(value) > 0, diags
+}
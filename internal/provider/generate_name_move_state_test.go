@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+func TestGenerateNameMoveStateFromAzurecafName(t *testing.T) {
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("unable to create provider server: %s", err)
+	}
+
+	sourceState := &tfprotov6.RawState{
+		JSON: []byte(`{"id":"abc","name":"webapp","resource_type":"azurerm_resource_group","prefixes":["man"],` +
+			`"suffixes":["dev","euw"],"random_length":0,"clean_input":true,"result":"rg-man-webapp-dev-euw"}`),
+	}
+
+	// Sources of other providers are not accepted.
+	resp, err := server.MoveResourceState(ctx, &tfprotov6.MoveResourceStateRequest{
+		SourceProviderAddress: "registry.terraform.io/hashicorp/random",
+		SourceTypeName:        azurecafNameType,
+		SourceState:           sourceState,
+		TargetTypeName:        "proactnaming_generate_name",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(resp.Diagnostics) == 0 || resp.TargetState != nil {
+		t.Fatalf("expected other providers to be rejected, got %+v", resp)
+	}
+
+	resp, err = server.MoveResourceState(ctx, &tfprotov6.MoveResourceStateRequest{
+		SourceProviderAddress: "registry.terraform.io/aztfmod/azurecaf",
+		SourceTypeName:        azurecafNameType,
+		SourceState:           sourceState,
+		TargetTypeName:        "proactnaming_generate_name",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	var schemaResp resource.SchemaResponse
+	(&generateName{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	value, err := resp.TargetState.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("unable to read moved state: %s", err)
+	}
+
+	var state generateNameModel
+	if diags := (tfsdk.State{Schema: schemaResp.Schema, Raw: value}).Get(ctx, &state); diags.HasError() {
+		t.Fatalf("unable to read moved state: %v", diags)
+	}
+
+	expected := map[string]types.String{
+		"organization":  types.StringValue("man"),
+		"resource_type": types.StringValue("rg"),
+		"application":   types.StringValue("webapp"),
+		"environment":   types.StringValue("dev"),
+		"location":      types.StringValue("euw"),
+		"instance":      types.StringNull(),
+		"resource_name": types.StringValue("rg-man-webapp-dev-euw"),
+	}
+	actual := map[string]types.String{
+		"organization":  state.Organization,
+		"resource_type": state.ResourceType,
+		"application":   state.Application,
+		"environment":   state.Environment,
+		"location":      state.Location,
+		"instance":      state.Instance,
+		"resource_name": state.ResourceName,
+	}
+	for name, value := range expected {
+		if !actual[name].Equal(value) {
+			t.Errorf("expected %s %s, got %s", name, value, actual[name])
+		}
+	}
+	if !state.ID.IsNull() {
+		t.Errorf("expected the moved state to have no ID before it is registered, got %s", state.ID)
+	}
+	if !strings.Contains(string(resp.TargetPrivate), movedFromKey) {
+		t.Errorf("expected the moved state to be marked in private state, got %s", resp.TargetPrivate)
+	}
+}
+
+func TestGenerateNameRegisterMovedName(t *testing.T) {
+	tool, client := newFakeNamingTool(t)
+	r := &generateName{client: client}
+
+	plan := generateNameModel{
+		Organization: types.StringValue("man"),
+		ResourceType: types.StringValue("rg"),
+		Application:  types.StringValue("webapp"),
+		Function:     types.StringValue("app"),
+		Instance:     types.StringValue("001"),
+		Location:     types.StringValue("euw"),
+		Environment:  types.StringValue("dev"),
+		ResourceName: types.StringValue("man-rg-webapp-app-001-euw-dev"),
+		LockName:     types.BoolNull(),
+	}
+
+	// Components reproducing the existing name register it.
+	var diags diag.Diagnostics
+	matching := plan
+	r.registerMovedName(context.Background(), &diags, &matching)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if matching.ID.IsNull() || tool.count() != 1 {
+		t.Fatalf("expected the existing name to be registered, got ID %s and %d names", matching.ID, tool.count())
+	}
+
+	// Components generating another name fail without leaving an entry behind.
+	diags = nil
+	mismatch := plan
+	mismatch.ResourceName = types.StringValue("rg-man-webapp-dev-euw")
+	mismatch.Instance = types.StringValue("002")
+	r.registerMovedName(context.Background(), &diags, &mismatch)
+	if !diags.HasError() {
+		t.Fatal("expected an error for components that do not reproduce the existing name")
+	}
+	if tool.count() != 1 {
+		t.Errorf("expected the mismatching entry to be removed, got %d names", tool.count())
+	}
+
+	// A locked name must reproduce the existing name as well.
+	diags = nil
+	mismatch.LockName = types.BoolValue(true)
+	r.registerMovedName(context.Background(), &diags, &mismatch)
+	if !diags.HasError() {
+		t.Fatal("expected an error for a locked name that does not reproduce the existing name")
+	}
+	if tool.count() != 1 {
+		t.Errorf("expected the mismatching entry to be removed, got %d names", tool.count())
+	}
+}
+
+func TestGenerateNameCheckMovedName(t *testing.T) {
+	ctx := context.Background()
+	resetPlannedNames(t)
+	tool, client := newFakeNamingTool(t)
+	seedNamingConvention(tool)
+	tool.resourceTypes[1] = azurenamingtool.ResourceTypes{ID: 1, ShortName: "rg", ApplyDelimiter: true}
+	r := &generateName{client: client}
+
+	state := generateNameModel{
+		Organization:       types.StringValue("man"),
+		ResourceType:       types.StringValue("rg"),
+		Application:        types.StringValue("webapp"),
+		TruncationPriority: types.ListNull(types.StringType),
+		Keepers:            types.MapNull(types.StringType),
+		ResourceName:       types.StringValue("man-rg-webapp-dev"),
+		Success:            types.BoolValue(true),
+		Message:            types.StringValue("Moved from azurecaf_name."),
+		TruncationApplied:  types.StringValue(truncationNone),
+		OriginalName:       types.StringValue("man-rg-webapp-dev"),
+	}
+
+	var req resource.ModifyPlanRequest
+	initPrivateState(&req.Private)
+	if diags := req.Private.SetKey(ctx, movedFromKey, []byte(`{"source":"azurecaf_name"}`)); diags.HasError() {
+		t.Fatalf("unable to mark the state as moved: %v", diags)
+	}
+
+	for name, test := range map[string]struct {
+		instance    types.String
+		environment string
+		lockName    bool
+		valid       bool
+	}{
+		"matching":        {instance: types.StringValue("001"), environment: "dev", valid: true},
+		"locked mismatch": {instance: types.StringValue("001"), environment: "prd", lockName: true},
+		"missing":         {instance: types.StringNull(), environment: "dev"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := state
+			config.Function = types.StringNull()
+			config.Location = types.StringValue("euw")
+			config.Environment = types.StringValue(test.environment)
+			config.Instance = test.instance
+			config.LockName = types.BoolValue(test.lockName)
+
+			plan := config
+			if plan.Instance.IsNull() {
+				plan.Instance = types.StringUnknown()
+			}
+
+			resp := runModifyPlan(t, r, &state, &config, &plan, req.Private)
+			if resp.Diagnostics.HasError() == test.valid {
+				t.Errorf("expected valid=%t, got %v", test.valid, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -4,21 +4,35 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/proact-global/azurenamingtool-client-go"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource               = &generateName{}
-	_ resource.ResourceWithConfigure  = &generateName{}
-	_ resource.ResourceWithModifyPlan = &generateName{}
+	_ resource.Resource                 = &generateName{}
+	_ resource.ResourceWithConfigure    = &generateName{}
+	_ resource.ResourceWithModifyPlan   = &generateName{}
+	_ resource.ResourceWithUpgradeState = &generateName{}
+	_ resource.ResourceWithMoveState    = &generateName{}
+	_ resource.ResourceWithIdentity     = &generateName{}
+	_ resource.ResourceWithImportState  = &generateName{}
 )
 
 // NewGenerateName is a helper function to simplify the provider implementation.
@@ -42,11 +56,23 @@ type generateNameModel struct {
 	Location     types.String `tfsdk:"location"`
 	Environment  types.String `tfsdk:"environment"`
 
+	// Truncation settings.
+	Truncation         types.String `tfsdk:"truncation"`
+	TruncationPriority types.List   `tfsdk:"truncation_priority"`
+
+	// Lifecycle settings.
+	Keepers            types.Map  `tfsdk:"keepers"`
+	LockName           types.Bool `tfsdk:"lock_name"`
+	RetainOnDestroy    types.Bool `tfsdk:"retain_on_destroy"`
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+
 	// Output fields from the API.
-	ID           types.Int64  `tfsdk:"id"`
-	ResourceName types.String `tfsdk:"resource_name"`
-	Success      types.Bool   `tfsdk:"success"`
-	Message      types.String `tfsdk:"message"`
+	ID                types.Int64  `tfsdk:"id"`
+	ResourceName      types.String `tfsdk:"resource_name"`
+	Success           types.Bool   `tfsdk:"success"`
+	Message           types.String `tfsdk:"message"`
+	TruncationApplied types.String `tfsdk:"truncation_applied"`
+	OriginalName      types.String `tfsdk:"original_name"`
 }
 
 // Metadata returns the resource type name.
@@ -57,46 +83,112 @@ func (r *generateName) Metadata(_ context.Context, req resource.MetadataRequest,
 // Schema defines the schema for the resource.
 func (r *generateName) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     generateNameSchemaVersion,
 		Description: "Generates standardized Azure resource names using the Azure Naming Tool.",
 		MarkdownDescription: "Generates standardized Azure resource names using the Azure Naming Tool following organizational naming conventions.\n\n" +
 			"This resource creates names that comply with Azure naming rules and organizational standards. " +
-			"All input fields trigger resource replacement when changed, ensuring name consistency.",
+			"All name component fields trigger resource replacement when changed, ensuring name consistency, unless `lock_name` is set. " +
+			"Changes to only the case or surrounding whitespace of a component do not change the resource and keep the issued name. " +
+			"The components are stored in state as the Azure Naming Tool normalises them, in lower case and without surrounding whitespace, " +
+			"from the first refresh after they were applied.\n\n" +
+			"When resources of the configuration would receive the same name or components, the plan fails with a single error that lists " +
+			"every resource involved; the resource that plans last reports it.",
 		Attributes: map[string]schema.Attribute{
 			// Input attributes.
 			"organization": schema.StringAttribute{
 				Description:   "Organization identifier for the resource name.",
 				Required:      true,
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				PlanModifiers: []planmodifier.String{useStateForEquivalentComponent(), requiresReplaceUnlessNameLocked()},
 			},
 			"resource_type": schema.StringAttribute{
 				Description:   "Azure resource type short name (e.g., 'rg', 'st', 'vm').",
 				Required:      true,
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				PlanModifiers: []planmodifier.String{useStateForEquivalentComponent(), requiresReplaceUnlessNameLocked()},
 			},
 			"application": schema.StringAttribute{
-				Description:   "Application identifier for the resource name.",
+				Description: "Application identifier for the resource name. When a custom component named 'Application' is defined " +
+					"in the Azure Naming Tool, the value must respect its length limits and be one of its allowed values, if any.",
+				MarkdownDescription: "Application identifier for the resource name. When a custom component named `Application` is defined " +
+					"in the Azure Naming Tool, the value must respect its length limits and be one of its allowed values, if any.",
 				Required:      true,
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				PlanModifiers: []planmodifier.String{useStateForEquivalentComponent(), requiresReplaceUnlessNameLocked()},
 			},
 			"function": schema.StringAttribute{
 				Description:   "Function or purpose identifier for the resource name.",
 				Optional:      true,
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				PlanModifiers: []planmodifier.String{useStateForEquivalentComponent(), requiresReplaceUnlessNameLocked()},
 			},
 			"instance": schema.StringAttribute{
-				Description:   "Instance number or identifier for the resource name.",
-				Required:      true,
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Description: "Instance number or identifier for the resource name. " +
+					"When omitted, the next free zero-padded instance for the same components is allocated from the generated names log during apply, " +
+					"so the name of a new resource is only known after apply.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					useStateForEquivalentComponent(),
+					requiresReplaceUnlessNameLocked(),
+				},
 			},
 			"location": schema.StringAttribute{
 				Description:   "Azure region identifier (e.g., 'euw', 'eus').",
 				Required:      true,
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				PlanModifiers: []planmodifier.String{useStateForEquivalentComponent(), requiresReplaceUnlessNameLocked()},
 			},
 			"environment": schema.StringAttribute{
 				Description:   "Environment identifier (e.g., 'dev', 'test', 'prod').",
 				Required:      true,
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				PlanModifiers: []planmodifier.String{useStateForEquivalentComponent(), requiresReplaceUnlessNameLocked()},
+			},
+			"truncation": schema.StringAttribute{
+				Description: "Strategy used when the generated name exceeds the resource type's length_max. " +
+					"One of 'error', 'shorten_components' or 'hash'. Defaults to 'error'.",
+				MarkdownDescription: "Strategy used when the generated name exceeds the resource type's `length_max`. " +
+					"One of `error` (fail the operation), `shorten_components` (trim the components listed in `truncation_priority`, in order) " +
+					"or `hash` (replace the overflow with a short hash of the full name). Defaults to `error`.",
+				Optional:      true,
+				Validators:    []validator.String{StringOneOf(truncationError, truncationShortenComponents, truncationHash)},
+				PlanModifiers: []planmodifier.String{requiresReplaceUnlessNameLocked()},
+			},
+			"truncation_priority": schema.ListAttribute{
+				Description: "Components that may be shortened by the truncation strategy, in priority order. " +
+					"Allowed values are 'application' and 'function'. Defaults to ['application', 'function'].",
+				MarkdownDescription: "Components that may be shortened by the truncation strategy, in priority order. " +
+					"Allowed values are `application` and `function`. Defaults to `[\"application\", \"function\"]`.",
+				ElementType:   types.StringType,
+				Optional:      true,
+				Validators:    []validator.List{ListStringsOneOf(truncationComponents...)},
+				PlanModifiers: []planmodifier.List{requiresReplaceListUnlessNameLocked()},
+			},
+			"keepers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, force a new name to be registered even though the components stay the same. " +
+					"When instance is omitted, the new name also receives a new instance.",
+				MarkdownDescription: "Arbitrary map of values that, when changed, force a new name to be registered even though the components stay the same, " +
+					"like the `keepers` of the random provider. When `instance` is omitted, the new name also receives the next free instance, " +
+					"which avoids reusing a name that is still held by a soft-deleted Azure resource such as a Key Vault.",
+				ElementType:   types.StringType,
+				Optional:      true,
+				PlanModifiers: []planmodifier.Map{mapplanmodifier.RequiresReplace()},
+			},
+			"lock_name": schema.BoolAttribute{
+				Description: "When true, changes to the name components and truncation settings are applied in place and keep the issued name " +
+					"instead of registering a new one. Changes to keepers still force a new name.",
+				MarkdownDescription: "When `true`, changes to the name components and truncation settings are applied in place and keep the issued " +
+					"`resource_name` instead of registering a new one, e.g. to correct a typo without replacing dependent resources. " +
+					"The inputs in state then describe the desired components rather than the issued name. Changes to `keepers` still force a new name.",
+				Optional: true,
+			},
+			"retain_on_destroy": schema.BoolAttribute{
+				Description: "When true, destroying the resource only removes it from Terraform state and keeps the entry in the Azure Naming Tool.",
+				MarkdownDescription: "When `true`, destroying or replacing the resource only removes it from Terraform state " +
+					"and keeps the entry in the Azure Naming Tool, for example for audit purposes. Can be changed without replacing the resource.",
+				Optional: true,
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "When true, destroying or replacing the resource fails during apply until deletion_protection is unset.",
+				MarkdownDescription: "When `true`, plans that destroy or replace the resource show a warning and the apply fails until " +
+					"`deletion_protection` is unset and applied. Can be changed without replacing the resource.",
+				Optional: true,
 			},
 
 			// Output attributes.
@@ -116,6 +208,14 @@ func (r *generateName) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Description: "Message from the Azure Naming Tool API.",
 				Computed:    true,
 			},
+			"truncation_applied": schema.StringAttribute{
+				Description: "The truncation strategy that was applied to the generated name, or 'none' if the name fitted without truncation.",
+				Computed:    true,
+			},
+			"original_name": schema.StringAttribute{
+				Description: "The generated name before truncation was applied.",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -131,30 +231,53 @@ func (r *generateName) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// Now we actually generate and persist the name during Create (apply phase).
-	// This creates the persistent entry in Azure Naming Tool.
-	generateRequest := azurenamingtool.GenerateNameRequest{
-		ResourceOrg:         plan.Organization.ValueString(),
-		ResourceType:        plan.ResourceType.ValueString(),
-		ResourceEnvironment: plan.Environment.ValueString(),
-		ResourceFunction:    plan.Function.ValueString(),
-		ResourceInstance:    plan.Instance.ValueString(),
-		ResourceLocation:    plan.Location.ValueString(),
-		CustomComponents: azurenamingtool.GenerateNameRequestCustomComponents{
-			Application: plan.Application.ValueString(),
-		},
+	// Allocate an instance if none was configured, otherwise reserve the configured instance
+	// so that later allocations in this process skip it.
+	if plan.Instance.IsUnknown() {
+		instance, err := instances.allocate(readOnlyClient(r.client), newGenerateNameRequest(plan))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Allocate Instance",
+				fmt.Sprintf("An error occurred while allocating the next free instance: %s\n\n"+
+					"Set the instance attribute explicitly or verify that the API key can read the generated names log.", err.Error()),
+			)
+			return
+		}
+		plan.Instance = types.StringValue(instance)
+	} else {
+		instances.reserve(newGenerateNameRequest(plan))
 	}
 
-	generateResponse, err := r.client.GenerateName(generateRequest)
+	// Every name request is recorded in private state before it is sent, so that the entry the
+	// tool registers for it can be found when the response is lost or the provider fails before
+	// the state is complete.
+	record := func(pending pendingName) {
+		r.savePendingName(ctx, resp, plan, pending)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			resp.Diagnostics.AddError(
+				"Unexpected Provider Error",
+				fmt.Sprintf("The provider failed while generating the name: %v\n\n"+
+					"The name request is recorded in state, so that the next refresh adopts the entry the Azure Naming Tool "+
+					"registered for it. Please report this issue to the provider developers.", p),
+			)
+		}
+	}()
+
+	// Now we actually generate and persist the name during Create (apply phase).
+	// This creates the persistent entry in Azure Naming Tool.
+	generateResponse, err := r.generate(ctx, &plan, record)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Generate Name",
-			fmt.Sprintf("An error occurred while generating the name: %s\n\n"+
-				"Please verify:\n"+
-				"- Azure Naming Tool is accessible at the configured host\n"+
-				"- API key has sufficient permissions\n"+
-				"- Input parameters match your naming tool configuration", err.Error()),
-		)
+		var requestErr *nameRequestError
+		if errors.As(err, &requestErr) {
+			r.recoverLostName(ctx, resp, plan, requestErr)
+			return
+		}
+
+		// The tool answered the failed request, so it did not register a name for it.
+		addGenerateNameError(&resp.Diagnostics, "Unable to Generate Name", err)
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
@@ -167,9 +290,13 @@ func (r *generateName) Create(ctx context.Context, req resource.CreateRequest, r
 	// Set state to fully populated data.
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	diags = resp.Private.SetKey(ctx, pendingNameKey, nil)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setGenerateNameIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -183,32 +310,55 @@ func (r *generateName) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	// Moved states keep their existing name until it is registered during the next apply.
+	moved, diags := movedState(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || moved {
+		return
+	}
+
+	// Imported states only hold the ID, the rest is read from the generated names log.
+	if !state.ID.IsNull() && state.ResourceName.IsNull() {
+		found, err := r.readImported(&state)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Generated Name",
+				fmt.Sprintf("An error occurred while reading generated name %d from the generated names log: %s", state.ID.ValueInt64(), err.Error()),
+			)
+			return
+		}
+		if !found {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		diags = resp.State.Set(ctx, state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// A Create that failed without a response recorded its request. The entry the tool registered
+	// for it, if any, is adopted, so that the replacement of the tainted resource removes it.
+	pending, diags := pendingNameFrom(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if pending != nil {
+		r.recoverPendingName(ctx, resp, &state, *pending)
+		resp.Diagnostics.Append(setGenerateNameIdentity(ctx, resp.Identity, state.ID)...)
+		return
+	}
+
 	// Generate the name if it hasn't been generated yet OR if we need to populate missing fields.
 	// Check if ID is null - this indicates we need to create the persistent entry.
 	if state.ID.IsNull() || state.ID.IsUnknown() {
 		// Generate the name using the API to create a persistent entry.
-		generateRequest := azurenamingtool.GenerateNameRequest{
-			ResourceOrg:         state.Organization.ValueString(),
-			ResourceType:        state.ResourceType.ValueString(),
-			ResourceEnvironment: state.Environment.ValueString(),
-			ResourceFunction:    state.Function.ValueString(),
-			ResourceInstance:    state.Instance.ValueString(),
-			ResourceLocation:    state.Location.ValueString(),
-			CustomComponents: azurenamingtool.GenerateNameRequestCustomComponents{
-				Application: state.Application.ValueString(),
-			},
-		}
-
-		generateResponse, err := r.client.GenerateName(generateRequest)
+		generateResponse, err := r.generate(ctx, &state, nil)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Generate Name",
-				fmt.Sprintf("An error occurred while generating the name: %s\n\n"+
-					"Please verify:\n"+
-					"- Azure Naming Tool is accessible at the configured host\n"+
-					"- API key has sufficient permissions\n"+
-					"- Input parameters match your naming tool configuration", err.Error()),
-			)
+			addGenerateNameError(&resp.Diagnostics, "Unable to Generate Name", err)
 			return
 		}
 
@@ -226,21 +376,55 @@ func (r *generateName) Read(ctx context.Context, req resource.ReadRequest, resp
 		}
 	}
 
+	// The components are stored as the Azure Naming Tool normalises them. Configured values that
+	// only differ from them in case or surrounding whitespace plan the stored values.
+	state.normalizeComponents()
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// If ID is already populated, we keep the existing generated name.
 	// This ensures the name remains stable across reads unless explicitly regenerated.
+	resp.Diagnostics.Append(setGenerateNameIdentity(ctx, resp.Identity, state.ID)...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
-// Note: This function should not be called due to RequiresReplace plan modifiers.
-// on all input attributes, but is implemented for completeness.
+// Name components only change in place when lock_name is set or their case or whitespace changes,
+// otherwise only lifecycle settings such as retain_on_destroy and deletion_protection do.
+// The issued name is always kept.
 func (r *generateName) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Since all input attributes have RequiresReplace plan modifiers.
-	// this function should not be called. If it is called, we'll return an error.
-	resp.Diagnostics.AddError(
-		"Unexpected Update Operation",
-		"This resource does not support updates. All changes should trigger a replacement. "+
-			"If you see this error, please report it to the provider developers.",
-	)
+	var plan, state generateNameModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.keepIssuedName(state)
+
+	// Register the existing name of a moved state with the adopted components.
+	moved, diags := movedState(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if moved {
+		r.registerMovedName(ctx, &resp.Diagnostics, &plan)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		diags = resp.Private.SetKey(ctx, movedFromKey, nil)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setGenerateNameIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -254,6 +438,21 @@ func (r *generateName) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	// Protected names are only reported as a warning at plan time, so the deletion is rejected here.
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Generated Name Is Protected",
+			fmt.Sprintf("The generated name %q has deletion_protection enabled and cannot be deleted. "+
+				"Unset deletion_protection and apply the change before destroying or replacing the resource.", state.ResourceName.ValueString()),
+		)
+		return
+	}
+
+	// Retained names are only removed from state and stay registered in the tool.
+	if state.RetainOnDestroy.ValueBool() {
+		return
+	}
+
 	// If ID is null, it means the entry was already cleaned up during Read.
 	// This is expected behavior for our cleanup strategy, so we just return success.
 	if state.ID.IsNull() || state.ID.IsUnknown() {
@@ -264,6 +463,10 @@ func (r *generateName) Delete(ctx context.Context, req resource.DeleteRequest, r
 	// Delete the generated name using the ID.
 	id := state.ID.ValueInt64()
 
+	// A replacement without a configured instance is allocated after this deletion, so the
+	// instance is reserved to keep it from receiving the deleted name again.
+	instances.reserve(newGenerateNameRequest(state))
+
 	deleteRequest := azurenamingtool.DeleteGeneratedNameRequest{
 		ID: id,
 	}
@@ -286,13 +489,23 @@ func (r *generateName) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 // ModifyPlan generates a preview of the name during planning to show what the new name will be.
 func (r *generateName) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	// Skip for destroy operations.
+	var plan, state generateNameModel
+
+	if !req.State.Raw.IsNull() {
+		diags := req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Check deletion protection and retention for destroy operations, and skip the rest.
 	if req.Plan.Raw.IsNull() {
+		checkNameRemoval(&resp.Diagnostics, state, "destroy")
+		r.checkNameDeletion(&resp.Diagnostics, state, "destroy")
 		return
 	}
 
-	var plan generateNameModel
-
 	// Get the planned configuration.
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -300,6 +513,59 @@ func (r *generateName) ModifyPlan(ctx context.Context, req resource.ModifyPlanRe
 		return
 	}
 
+	// Moved states adopt the configured components in place.
+	moved, diags := movedState(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owner, diags := planOwnerID(ctx, req, resp)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		// The RequiresReplace paths of the attribute plan modifiers are not visible here,
+		// so a replacement is detected by comparing the inputs that require one.
+		if !moved && plan.replaces(state) {
+			checkNameRemoval(&resp.Diagnostics, state, "replace")
+			r.checkNameDeletion(&resp.Diagnostics, state, "replace")
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// Changed keepers must not reuse the previous name, so an allocated instance
+			// kept from state by UseStateForUnknown is allocated again.
+			if !plan.Keepers.Equal(state.Keepers) {
+				var configInstance types.String
+				diags = req.Config.GetAttribute(ctx, path.Root("instance"), &configInstance)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				if configInstance.IsNull() {
+					plan.Instance = types.StringUnknown()
+				}
+			}
+		} else {
+			// In-place updates only change lifecycle settings and keep the issued name.
+			plan.keepIssuedName(state)
+			if moved {
+				// The ID is assigned when the existing name is registered during apply.
+				plan.ID = types.Int64Unknown()
+			} else {
+				// The issued name stays in use, so new resources of the configuration must not plan it.
+				checkPlannedName(&resp.Diagnostics, state, state.ResourceName.ValueString(), owner)
+
+				diags = resp.Plan.Set(ctx, plan)
+				resp.Diagnostics.Append(diags...)
+				return
+			}
+		}
+	}
+
 	// Skip if we don't have all required fields.
 	if plan.Organization.IsNull() || plan.ResourceType.IsNull() ||
 		plan.Application.IsNull() || plan.Instance.IsNull() ||
@@ -312,37 +578,50 @@ func (r *generateName) ModifyPlan(ctx context.Context, req resource.ModifyPlanRe
 		return
 	}
 
+	// Validate the application against its custom component definition, if any.
+	if !plan.Application.IsUnknown() {
+		checkCustomComponentValue(&resp.Diagnostics, readOnlyClient(r.client), path.Root("application"), "Application",
+			strings.TrimSpace(plan.Application.ValueString()))
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Moved states keep their existing name, which is registered during apply. The configured
+	// components must reproduce it, which is checked here so that a mismatch fails the plan.
+	if moved {
+		r.checkMovedName(ctx, &resp.Diagnostics, req.Config, plan)
+		if !resp.Diagnostics.HasError() && plan.componentsKnown() {
+			checkPlannedName(&resp.Diagnostics, plan, plan.ResourceName.ValueString(), owner)
+		}
+
+		diags = resp.Plan.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	// An omitted or unknown instance is allocated during apply, when the generated names log
+	// holds the names created earlier in the run, so the name cannot be previewed yet.
+	// Allocating during plan would not survive the separate apply process and its parallel walk.
+	if plan.Instance.IsUnknown() {
+		diags = resp.Plan.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
 	// Since names are now generated during Read, we'll generate a preview here.
 	// to show users what the name will look like in the plan output.
 	if plan.ResourceName.IsUnknown() {
 		// Generate a preview of the name using the API.
-		generateRequest := azurenamingtool.GenerateNameRequest{
-			ResourceOrg:         plan.Organization.ValueString(),
-			ResourceType:        plan.ResourceType.ValueString(),
-			ResourceEnvironment: plan.Environment.ValueString(),
-			ResourceFunction:    plan.Function.ValueString(),
-			ResourceInstance:    plan.Instance.ValueString(),
-			ResourceLocation:    plan.Location.ValueString(),
-			CustomComponents: azurenamingtool.GenerateNameRequestCustomComponents{
-				Application: plan.Application.ValueString(),
-			},
-		}
-
-		generateResponse, err := r.client.GenerateName(generateRequest)
+		generateResponse, err := r.generate(ctx, &plan, nil)
 		if err != nil {
 			// Fail the plan if we can't reach the API - this indicates a configuration problem.
-			resp.Diagnostics.AddError(
-				"Unable to Generate Name Preview",
-				fmt.Sprintf("An error occurred while generating the name preview: %s\n\n"+
-					"Please verify:\n"+
-					"- Azure Naming Tool is accessible at the configured host\n"+
-					"- API key has sufficient permissions\n"+
-					"- Input parameters match your naming tool configuration", err.Error()),
-			)
+			addGenerateNameError(&resp.Diagnostics, "Unable to Generate Name Preview", err)
 			return
 		}
 
 		// Update the plan with the generated name preview.
+		// The truncation attributes are already set on the plan by generate.
 		plan.ResourceName = types.StringValue(generateResponse.ResourceName)
 		// Note: We don't set other computed values here since this is just a preview.
 
@@ -356,12 +635,454 @@ func (r *generateName) ModifyPlan(ctx context.Context, req resource.ModifyPlanRe
 			_, _ = r.client.DeleteName(deleteRequest)
 		}
 
+		// Fail the plan if another resource of this configuration would receive the same name.
+		if plan.componentsKnown() {
+			checkPlannedName(&resp.Diagnostics, plan, generateResponse.ResourceName, owner)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
 		// Set the updated plan with the preview name.
 		diags = resp.Plan.Set(ctx, plan)
 		resp.Diagnostics.Append(diags...)
 	}
 }
 
+// generate requests a name from the Azure Naming Tool and applies the configured truncation
+// strategy when the name exceeds the resource type's length_max. The truncation_applied and
+// original_name attributes of the model are updated to describe the outcome. When record is
+// not nil, it is called with every name request before the request is sent.
+func (r *generateName) generate(ctx context.Context, model *generateNameModel, record func(pendingName)) (*azurenamingtool.GenerateNameResponse, error) {
+	generateRequest := newGenerateNameRequest(*model)
+
+	generateResponse, err := r.requestName(generateRequest, record)
+	if err != nil {
+		return nil, err
+	}
+
+	model.OriginalName = types.StringValue(generateResponse.ResourceName)
+	model.TruncationApplied = types.StringValue(truncationNone)
+
+	// The tool validates the length itself, so a successful response always fits.
+	if generateResponse.Success {
+		return generateResponse, nil
+	}
+
+	maxLength, applyDelimiter, err := resourceTypeLimits(readOnlyClient(r.client), model.ResourceType.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("unable to read resource types: %w", err)
+	}
+	if maxLength == 0 {
+		return generateResponse, nil
+	}
+
+	// A failed response does not necessarily carry the rejected name, so the full name is
+	// rendered from the components and the naming convention of the tool.
+	fullName, err := requestedName(readOnlyClient(r.client), generateRequest, applyDelimiter)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the naming convention: %w", err)
+	}
+
+	// Failures unrelated to the length are reported as before through success and message.
+	overflow := len(fullName) - maxLength
+	if overflow <= 0 {
+		return generateResponse, nil
+	}
+
+	model.OriginalName = types.StringValue(fullName)
+	lengthErr := &nameLengthError{
+		name:         fullName,
+		resourceType: model.ResourceType.ValueString(),
+		maxLength:    maxLength,
+	}
+
+	priority := truncationComponents
+	if !model.TruncationPriority.IsNull() && !model.TruncationPriority.IsUnknown() {
+		diags := model.TruncationPriority.ElementsAs(ctx, &priority, false)
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to read truncation_priority")
+		}
+	}
+
+	strategy := model.Truncation.ValueString()
+	switch strategy {
+	case truncationShortenComponents:
+		var remaining int
+		generateRequest, remaining = shortenComponents(generateRequest, priority, overflow)
+		if remaining > 0 {
+			return nil, lengthErr
+		}
+	case truncationHash:
+		var ok bool
+		generateRequest, ok = hashComponents(generateRequest, priority, fullName, overflow)
+		if !ok {
+			return nil, lengthErr
+		}
+	default:
+		return nil, lengthErr
+	}
+
+	// Remove any entry the tool registered for the oversized name before requesting the truncated one.
+	if generateResponse.ResourceNameDetails.ID != 0 {
+		_, _ = r.client.DeleteName(azurenamingtool.DeleteGeneratedNameRequest{
+			ID: generateResponse.ResourceNameDetails.ID,
+		})
+	}
+
+	generateResponse, err = r.requestName(generateRequest, record)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(generateResponse.ResourceName) > maxLength {
+		lengthErr.name = generateResponse.ResourceName
+		return nil, lengthErr
+	}
+
+	model.TruncationApplied = types.StringValue(strategy)
+
+	return generateResponse, nil
+}
+
+// requestName generates a name for the request. A request failing without a response returns a
+// nameRequestError, as the tool may have registered the name before the response was lost.
+// When record is not nil, it is called with the pending request before the request is sent.
+func (r *generateName) requestName(request azurenamingtool.GenerateNameRequest, record func(pendingName)) (*azurenamingtool.GenerateNameResponse, error) {
+	pending := pendingName{Components: componentsKey(request), RequestedAt: time.Now()}
+	if record != nil {
+		record(pending)
+	}
+
+	generateResponse, err := generationClient(r.client).GenerateName(request)
+	if err != nil {
+		pending.FailedAt = time.Now()
+		return nil, &nameRequestError{pending: pending, err: err}
+	}
+	orphans.claim(generateResponse.ResourceNameDetails.ID)
+
+	return generateResponse, nil
+}
+
+// newGenerateNameRequest builds the Azure Naming Tool request from the resource model.
+func newGenerateNameRequest(model generateNameModel) azurenamingtool.GenerateNameRequest {
+	return azurenamingtool.GenerateNameRequest{
+		ResourceOrg:         strings.TrimSpace(model.Organization.ValueString()),
+		ResourceType:        strings.TrimSpace(model.ResourceType.ValueString()),
+		ResourceEnvironment: strings.TrimSpace(model.Environment.ValueString()),
+		ResourceFunction:    strings.TrimSpace(model.Function.ValueString()),
+		ResourceInstance:    strings.TrimSpace(model.Instance.ValueString()),
+		ResourceLocation:    strings.TrimSpace(model.Location.ValueString()),
+		CustomComponents: azurenamingtool.GenerateNameRequestCustomComponents{
+			Application: strings.TrimSpace(model.Application.ValueString()),
+		},
+	}
+}
+
+// savePendingName saves the partial state of a Create whose name request has not been answered,
+// with the request recorded in private state.
+func (r *generateName) savePendingName(ctx context.Context, resp *resource.CreateResponse, plan generateNameModel, pending pendingName) {
+	plan.ID = types.Int64Null()
+	plan.ResourceName = types.StringNull()
+	plan.Success = types.BoolValue(false)
+	plan.Message = types.StringValue("The name request was not answered.")
+	if plan.TruncationApplied.IsUnknown() {
+		plan.TruncationApplied = types.StringNull()
+	}
+	if plan.OriginalName.IsUnknown() {
+		plan.OriginalName = types.StringNull()
+	}
+
+	value, err := json.Marshal(pending)
+	if err != nil {
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	diags = resp.Private.SetKey(ctx, pendingNameKey, value)
+	resp.Diagnostics.Append(diags...)
+}
+
+// recoverLostName completes a Create whose name request failed without a response. The entry the
+// tool registered for the request is adopted right away, so that the resource is created with it.
+// When the generated names log cannot be read, the Create fails with the request recorded in
+// private state, and the next refresh adopts the entry instead.
+func (r *generateName) recoverLostName(ctx context.Context, resp *resource.CreateResponse, plan generateNameModel, requestErr *nameRequestError) {
+	entry, err := orphans.find(readOnlyClient(r.client), requestErr.pending)
+	switch {
+	case err != nil:
+		addGenerateNameError(&resp.Diagnostics, "Unable to Generate Name", requestErr)
+		resp.Diagnostics.AddWarning(
+			"Unable to Recover Pending Generated Name",
+			fmt.Sprintf("The name request may have registered a name in the Azure Naming Tool, "+
+				"but the generated names log could not be searched for it: %s\n\n"+
+				"The request is recorded in state, so that the next refresh adopts the entry.", err.Error()),
+		)
+		r.savePendingName(ctx, resp, plan, requestErr.pending)
+	case entry == nil:
+		// The tool did not register a name for the request.
+		addGenerateNameError(&resp.Diagnostics, "Unable to Generate Name", requestErr)
+		resp.State.RemoveResource(ctx)
+	default:
+		plan.adoptEntry(*entry)
+		resp.Diagnostics.AddWarning(
+			"Adopted Pending Generated Name",
+			fmt.Sprintf("The response to the name request was lost after the Azure Naming Tool registered %q with ID %d. "+
+				"The entry has been adopted.", entry.ResourceName, entry.ID),
+		)
+
+		diags := resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		diags = resp.Private.SetKey(ctx, pendingNameKey, nil)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(setGenerateNameIdentity(ctx, resp.Identity, plan.ID)...)
+	}
+}
+
+// recoverPendingName adopts the entry registered for the pending name request of a failed Create
+// and removes the request from private state. When the generated names log cannot be read, a warning
+// is added instead, and the replacement of the tainted resource requests a new name as usual.
+func (r *generateName) recoverPendingName(ctx context.Context, resp *resource.ReadResponse, state *generateNameModel, pending pendingName) {
+	entry, err := orphans.find(readOnlyClient(r.client), pending)
+	switch {
+	case err != nil:
+		resp.Diagnostics.AddWarning(
+			"Unable to Recover Pending Generated Name",
+			fmt.Sprintf("The name request of the failed create may have registered a name in the Azure Naming Tool, "+
+				"but the generated names log could not be searched for it: %s\n\n"+
+				"The resource is replaced with a new name. Remove the entry with the components of this resource "+
+				"from the generated names log if it was registered.", err.Error()),
+		)
+	case entry != nil:
+		state.adoptEntry(*entry)
+		resp.Diagnostics.AddWarning(
+			"Adopted Pending Generated Name",
+			fmt.Sprintf("The name request of the failed create registered %q with ID %d in the Azure Naming Tool before "+
+				"its response was lost. The entry has been adopted, so that replacing the resource removes it.",
+				entry.ResourceName, entry.ID),
+		)
+	}
+
+	diags := resp.Private.SetKey(ctx, pendingNameKey, nil)
+	resp.Diagnostics.Append(diags...)
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// adoptEntry sets the name of the model to the generated names log entry registered by a name
+// request whose response was lost.
+func (m *generateNameModel) adoptEntry(entry generatedNameLogEntry) {
+	m.ID = types.Int64Value(entry.ID)
+	m.ResourceName = types.StringValue(entry.ResourceName)
+	m.Success = types.BoolValue(true)
+	m.Message = types.StringValue("Adopted the name registered by a name request whose response was lost.")
+	if m.OriginalName.IsNull() || m.OriginalName.IsUnknown() {
+		m.OriginalName = types.StringValue(entry.ResourceName)
+	}
+	if m.TruncationApplied.IsNull() || m.TruncationApplied.IsUnknown() {
+		m.TruncationApplied = types.StringValue(truncationNone)
+	}
+}
+
+// checkNameRemoval adds warnings for plans that destroy or replace a protected or retained name.
+// Protected names are only rejected by Delete, so that the rest of the plan can still be reviewed.
+func checkNameRemoval(diags *diag.Diagnostics, state generateNameModel, action string) {
+	if state.DeletionProtection.ValueBool() {
+		diags.AddAttributeWarning(
+			path.Root("deletion_protection"),
+			"Generated Name Is Protected",
+			fmt.Sprintf("This plan would %s the resource and delete the generated name %q, which has deletion_protection enabled, "+
+				"so the apply will fail. Unset deletion_protection and apply the change first.", action, state.ResourceName.ValueString()),
+		)
+		return
+	}
+
+	if state.RetainOnDestroy.ValueBool() {
+		diags.AddAttributeWarning(
+			path.Root("retain_on_destroy"),
+			"Generated Name Will Be Retained",
+			fmt.Sprintf("This plan would %s the resource. The generated name %q has retain_on_destroy enabled, "+
+				"so it is only removed from Terraform state and stays registered in the Azure Naming Tool.", action, state.ResourceName.ValueString()),
+		)
+	}
+}
+
+// checkNameDeletion adds a diagnostic when the plan deletes the name of state from the tool
+// without an admin password. Protected and retained names are never deleted.
+func (r *generateName) checkNameDeletion(diags *diag.Diagnostics, state generateNameModel, action string) {
+	if state.ID.IsNull() || state.DeletionProtection.ValueBool() || state.RetainOnDestroy.ValueBool() {
+		return
+	}
+	checkAdminPassword(diags, r.client, action, state.ResourceName.ValueString())
+}
+
+// replaces reports whether any input that requires replacement differs between the models.
+// When the name is locked, only keepers require replacement.
+func (m generateNameModel) replaces(state generateNameModel) bool {
+	if m.LockName.ValueBool() {
+		return !m.Keepers.Equal(state.Keepers)
+	}
+	return !componentEqual(m.Organization, state.Organization) || !componentEqual(m.ResourceType, state.ResourceType) ||
+		!componentEqual(m.Application, state.Application) || !componentEqual(m.Function, state.Function) ||
+		!componentEqual(m.Instance, state.Instance) || !componentEqual(m.Location, state.Location) ||
+		!componentEqual(m.Environment, state.Environment) || !m.Truncation.Equal(state.Truncation) ||
+		!m.TruncationPriority.Equal(state.TruncationPriority) || !m.Keepers.Equal(state.Keepers)
+}
+
+// componentEqual reports whether two component values are equal, ignoring case and
+// surrounding whitespace, which the Azure Naming Tool normalises.
+func componentEqual(a, b types.String) bool {
+	if a.IsNull() || a.IsUnknown() || b.IsNull() || b.IsUnknown() {
+		return a.Equal(b)
+	}
+	return strings.EqualFold(strings.TrimSpace(a.ValueString()), strings.TrimSpace(b.ValueString()))
+}
+
+// normalizeComponentValue returns the component value as the Azure Naming Tool normalises it,
+// in lower case and without surrounding whitespace.
+func normalizeComponentValue(value types.String) types.String {
+	if value.IsNull() || value.IsUnknown() {
+		return value
+	}
+	return types.StringValue(strings.ToLower(strings.TrimSpace(value.ValueString())))
+}
+
+// normalizeComponents normalises the component values of the model, see normalizeComponentValue.
+func (m *generateNameModel) normalizeComponents() {
+	for _, component := range []*types.String{&m.Organization, &m.ResourceType, &m.Application, &m.Function,
+		&m.Instance, &m.Location, &m.Environment} {
+		*component = normalizeComponentValue(*component)
+	}
+}
+
+// useStateForEquivalentComponent returns a plan modifier that keeps the stored component value
+// when the configured value only differs in case or surrounding whitespace, so that the plan
+// shows no difference for it.
+func useStateForEquivalentComponent() planmodifier.String {
+	return equivalentComponentModifier{}
+}
+
+// equivalentComponentModifier implements useStateForEquivalentComponent.
+type equivalentComponentModifier struct{}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m equivalentComponentModifier) Description(_ context.Context) string {
+	return "Changes to only the case or surrounding whitespace of this value are ignored."
+}
+
+// MarkdownDescription returns a markdown formatted description of the modifier's behavior.
+func (m equivalentComponentModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString plans the stored value when it is equivalent to the configured value.
+func (m equivalentComponentModifier) PlanModifyString(_ context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+	if componentEqual(req.PlanValue, req.StateValue) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// requiresReplaceUnlessNameLocked returns a plan modifier that requires replacement when the
+// value changes, unless lock_name is set or only the case or surrounding whitespace changed.
+// Such changes are applied in place and keep the issued name.
+func requiresReplaceUnlessNameLocked() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			if componentEqual(req.PlanValue, req.StateValue) {
+				return
+			}
+			locked, diags := nameLocked(ctx, req.Plan, req.Private)
+			resp.Diagnostics.Append(diags...)
+			resp.RequiresReplace = !locked
+		},
+		"Changing this value requires replacement unless lock_name is set or only its case or surrounding whitespace changes.",
+		"Changing this value requires replacement unless `lock_name` is set or only its case or surrounding whitespace changes.",
+	)
+}
+
+// requiresReplaceListUnlessNameLocked is the list variant of requiresReplaceUnlessNameLocked.
+func requiresReplaceListUnlessNameLocked() planmodifier.List {
+	return listplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+			locked, diags := nameLocked(ctx, req.Plan, req.Private)
+			resp.Diagnostics.Append(diags...)
+			resp.RequiresReplace = !locked
+		},
+		"Changing this value requires replacement unless lock_name is set.",
+		"Changing this value requires replacement unless `lock_name` is set.",
+	)
+}
+
+// nameLocked reports whether lock_name is set in the plan or the state was moved from another
+// resource type, whose first plan adopts the configured components in place.
+func nameLocked(ctx context.Context, plan tfsdk.Plan, private privateStateReader) (bool, diag.Diagnostics) {
+	moved, diags := movedState(ctx, private)
+	if moved || diags.HasError() {
+		return moved, diags
+	}
+
+	var locked types.Bool
+	diags = plan.GetAttribute(ctx, path.Root("lock_name"), &locked)
+	return locked.ValueBool(), diags
+}
+
+// keepIssuedName copies the values describing the issued name from state.
+func (m *generateNameModel) keepIssuedName(state generateNameModel) {
+	m.ID = state.ID
+	m.ResourceName = state.ResourceName
+	m.Success = state.Success
+	m.Message = state.Message
+	m.TruncationApplied = state.TruncationApplied
+	m.OriginalName = state.OriginalName
+}
+
+// componentsKnown reports whether every component of the model is known.
+func (m generateNameModel) componentsKnown() bool {
+	for _, value := range []types.String{m.Organization, m.ResourceType, m.Application, m.Function, m.Instance, m.Location, m.Environment} {
+		if value.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// checkPlannedName registers the components and name of a planned instance in the plan registry
+// and adds an error diagnostic if another resource of the configuration planned the same.
+func checkPlannedName(diags *diag.Diagnostics, model generateNameModel, name, owner string) {
+	request := newGenerateNameRequest(model)
+	plannedNames.checkDuplicate(diags, request, name, planOwner{
+		id:          owner,
+		description: describeRequest("proactnaming_generate_name", request),
+	})
+}
+
+// addGenerateNameError adds a diagnostic describing a failed name generation.
+func addGenerateNameError(diags *diag.Diagnostics, summary string, err error) {
+	var lengthErr *nameLengthError
+	if errors.As(err, &lengthErr) {
+		diags.AddError(
+			"Generated Name Too Long",
+			fmt.Sprintf("%s.\n\n"+
+				"Shorten the application or function components, or set truncation to "+
+				"'shorten_components' or 'hash' to let the provider shorten the name.", err.Error()),
+		)
+		return
+	}
+
+	diags.AddError(
+		summary,
+		fmt.Sprintf("An error occurred while generating the name: %s\n\n"+
+			"Please verify:\n"+
+			"- Azure Naming Tool is accessible at the configured host\n"+
+			"- API key has sufficient permissions\n"+
+			"- Input parameters match your naming tool configuration", err.Error()),
+	)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *generateName) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Add a nil check when handling ProviderData because Terraform.
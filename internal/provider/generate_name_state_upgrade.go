@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// generateNameSchemaVersion is the current schema version of proactnaming_generate_name.
+// Increment it together with a new entry in UpgradeState whenever existing states need migrating.
+const generateNameSchemaVersion = 1
+
+// generateNameModelV0 maps the schema of proactnaming_generate_name version 0, which only
+// held the name components and the response of the Azure Naming Tool.
+type generateNameModelV0 struct {
+	Organization types.String `tfsdk:"organization"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	Application  types.String `tfsdk:"application"`
+	Function     types.String `tfsdk:"function"`
+	Instance     types.String `tfsdk:"instance"`
+	Location     types.String `tfsdk:"location"`
+	Environment  types.String `tfsdk:"environment"`
+
+	ID           types.Int64  `tfsdk:"id"`
+	ResourceName types.String `tfsdk:"resource_name"`
+	Success      types.Bool   `tfsdk:"success"`
+	Message      types.String `tfsdk:"message"`
+}
+
+// generateNameSchemaV0 returns the schema of proactnaming_generate_name version 0.
+func generateNameSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"organization":  schema.StringAttribute{Required: true},
+			"resource_type": schema.StringAttribute{Required: true},
+			"application":   schema.StringAttribute{Required: true},
+			"function":      schema.StringAttribute{Optional: true},
+			"instance":      schema.StringAttribute{Required: true},
+			"location":      schema.StringAttribute{Required: true},
+			"environment":   schema.StringAttribute{Required: true},
+			"id":            schema.Int64Attribute{Computed: true},
+			"resource_name": schema.StringAttribute{Computed: true},
+			"success":       schema.BoolAttribute{Computed: true},
+			"message":       schema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+// UpgradeState returns the upgraders migrating earlier states to the current schema version.
+func (r *generateName) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   generateNameSchemaV0(),
+			StateUpgrader: r.upgradeStateV0,
+		},
+	}
+}
+
+// upgradeStateV0 migrates version 0 states. Names of version 0 were never truncated, so the
+// original name is the issued name. Version 0 cleared the ID when the entry was cleaned up during
+// Read; the ID is recovered from the generated names log where possible so that Read does not
+// register the name again.
+func (r *generateName) upgradeStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior generateNameModelV0
+
+	diags := req.State.Get(ctx, &prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := generateNameModel{
+		Organization:       prior.Organization,
+		ResourceType:       prior.ResourceType,
+		Application:        prior.Application,
+		Function:           prior.Function,
+		Instance:           prior.Instance,
+		Location:           prior.Location,
+		Environment:        prior.Environment,
+		Truncation:         types.StringNull(),
+		TruncationPriority: types.ListNull(types.StringType),
+		Keepers:            types.MapNull(types.StringType),
+		LockName:           types.BoolNull(),
+		RetainOnDestroy:    types.BoolNull(),
+		DeletionProtection: types.BoolNull(),
+		ID:                 prior.ID,
+		ResourceName:       prior.ResourceName,
+		Success:            prior.Success,
+		Message:            prior.Message,
+		TruncationApplied:  types.StringValue(truncationNone),
+		OriginalName:       prior.ResourceName,
+	}
+
+	if state.ID.IsNull() && state.ResourceName.ValueString() != "" {
+		r.recoverID(&resp.Diagnostics, &state)
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// recoverID looks up the ID of the issued name in the generated names log. When the entry
+// cannot be found, the ID stays null and Read registers the name again as before.
+func (r *generateName) recoverID(diags *diag.Diagnostics, state *generateNameModel) {
+	if r.client == nil {
+		return
+	}
+
+	entries, err := getGeneratedNamesLog(readOnlyClient(r.client))
+	if err != nil {
+		diags.AddWarning(
+			"Unable to Recover Generated Name ID",
+			fmt.Sprintf("The state of %q has no ID and the generated names log could not be read: %s\n\n"+
+				"The name will be registered again during the next refresh.", state.ResourceName.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	key := componentsKey(newGenerateNameRequest(*state))
+	if entry := findLogEntry(entries, state.ResourceName.ValueString(), key); entry != nil {
+		state.ID = types.Int64Value(entry.ID)
+	}
+}
+
+// findLogEntry returns the most recently registered entry with the given name and components key.
+func findLogEntry(entries []generatedNameLogEntry, name, key string) *generatedNameLogEntry {
+	var found *generatedNameLogEntry
+	for i, entry := range entries {
+		if !strings.EqualFold(entry.ResourceName, name) || logEntryComponentsKey(entry) != key {
+			continue
+		}
+		if found == nil || entry.ID > found.ID {
+			found = &entries[i]
+		}
+	}
+	return found
+}
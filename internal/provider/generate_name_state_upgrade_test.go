@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+func TestGenerateNameUpgradeStateV0(t *testing.T) {
+	tool, client := newFakeNamingTool(t)
+
+	request := azurenamingtool.GenerateNameRequest{
+		ResourceOrg:         "man",
+		ResourceType:        "rg",
+		ResourceInstance:    "001",
+		ResourceLocation:    "euw",
+		ResourceEnvironment: "dev",
+		CustomComponents: azurenamingtool.GenerateNameRequestCustomComponents{
+			Application: "webapp",
+		},
+	}
+	issued, err := client.GenerateName(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tests := map[string]struct {
+		id           types.Int64
+		resourceName string
+		instance     string
+		expectedID   types.Int64
+	}{
+		"with id": {
+			id:           types.Int64Value(42),
+			resourceName: "man-rg-webapp--001-euw-dev",
+			instance:     "001",
+			expectedID:   types.Int64Value(42),
+		},
+		"id recovered from log": {
+			id:           types.Int64Null(),
+			resourceName: issued.ResourceName,
+			instance:     "001",
+			expectedID:   types.Int64Value(issued.ResourceNameDetails.ID),
+		},
+		"id not in log": {
+			id:           types.Int64Null(),
+			resourceName: "man-rg-webapp--002-euw-dev",
+			instance:     "002",
+			expectedID:   types.Int64Null(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &generateName{client: client}
+			prior := generateNameModelV0{
+				Organization: types.StringValue("man"),
+				ResourceType: types.StringValue("rg"),
+				Application:  types.StringValue("webapp"),
+				Function:     types.StringNull(),
+				Instance:     types.StringValue(test.instance),
+				Location:     types.StringValue("euw"),
+				Environment:  types.StringValue("dev"),
+				ID:           test.id,
+				ResourceName: types.StringValue(test.resourceName),
+				Success:      types.BoolValue(true),
+				Message:      types.StringValue(""),
+			}
+
+			state := upgradeGenerateNameState(t, r, prior)
+
+			if !state.ID.Equal(test.expectedID) {
+				t.Errorf("expected id %s, got %s", test.expectedID, state.ID)
+			}
+			if state.ResourceName.ValueString() != test.resourceName || state.OriginalName.ValueString() != test.resourceName {
+				t.Errorf("expected resource_name and original_name %q, got %s and %s", test.resourceName, state.ResourceName, state.OriginalName)
+			}
+			if state.TruncationApplied.ValueString() != truncationNone {
+				t.Errorf("expected truncation_applied %q, got %s", truncationNone, state.TruncationApplied)
+			}
+			if !state.Truncation.IsNull() || !state.Keepers.IsNull() || !state.LockName.IsNull() || !state.DeletionProtection.IsNull() {
+				t.Errorf("expected settings added after version 0 to be null, got %+v", state)
+			}
+		})
+	}
+
+	if got := tool.count(); got != 1 {
+		t.Errorf("expected upgrading states not to register names, got %d names", got)
+	}
+}
+
+// upgradeGenerateNameState runs the version 0 state upgrader of r on prior.
+func upgradeGenerateNameState(t *testing.T, r *generateName, prior generateNameModelV0) generateNameModel {
+	t.Helper()
+	ctx := context.Background()
+
+	upgrader := r.UpgradeState(ctx)[0]
+
+	req := resource.UpgradeStateRequest{
+		State: &tfsdk.State{
+			Schema: *upgrader.PriorSchema,
+			Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+		},
+	}
+	if diags := req.State.Set(ctx, prior); diags.HasError() {
+		t.Fatalf("unable to set prior state: %v", diags)
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var state generateNameModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("unable to read upgraded state: %v", diags)
+	}
+	return state
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// defaultInstanceWidth is the zero-padded width of allocated instances, e.g. "001".
+const defaultInstanceWidth = 3
+
+// instanceAllocator hands out free instance numbers for a component set. Allocation is
+// serialised within the provider process and allocated instances are reserved until the
+// process exits, so resources planned or created in the same run never receive the same instance.
+type instanceAllocator struct {
+	mu       sync.Mutex
+	reserved map[string]map[int]bool
+}
+
+// instances is the process-wide instance allocator.
+var instances = &instanceAllocator{
+	reserved: make(map[string]map[int]bool),
+}
+
+// instanceKey identifies a component set, ignoring the instance itself.
+func instanceKey(request azurenamingtool.GenerateNameRequest) string {
+	return strings.ToLower(strings.Join([]string{
+		request.ResourceOrg,
+		request.ResourceType,
+		request.CustomComponents.Application,
+		request.ResourceFunction,
+		request.ResourceLocation,
+		request.ResourceEnvironment,
+	}, "|"))
+}
+
+// logEntryInstanceKey returns the instanceKey of a generated names log entry.
+func logEntryInstanceKey(entry generatedNameLogEntry) string {
+	return instanceKey(azurenamingtool.GenerateNameRequest{
+		ResourceOrg:         entry.component("organization"),
+		ResourceType:        entry.component("type"),
+		ResourceFunction:    entry.component("function"),
+		ResourceLocation:    entry.component("location"),
+		ResourceEnvironment: entry.component("environment"),
+		CustomComponents: azurenamingtool.GenerateNameRequestCustomComponents{
+			Application: entry.component("application"),
+		},
+	})
+}
+
+// allocate returns the next free instance for the component set of the request, based on
+// the generated names log and the instances already reserved by this process.
+func (a *instanceAllocator) allocate(client *azurenamingtool.Client, request azurenamingtool.GenerateNameRequest) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	entries, err := getGeneratedNamesLog(client)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the generated names log: %w", err)
+	}
+
+	key := instanceKey(request)
+
+	var used []string
+	for _, entry := range entries {
+		if logEntryInstanceKey(entry) == key {
+			used = append(used, entry.component("instance"))
+		}
+	}
+
+	instance, number := nextFreeInstance(used, a.reserved[key])
+
+	if a.reserved[key] == nil {
+		a.reserved[key] = make(map[int]bool)
+	}
+	a.reserved[key][number] = true
+
+	return instance, nil
+}
+
+// reserve marks an instance as taken by this process, e.g. when it was allocated
+// during plan and is now being created.
+func (a *instanceAllocator) reserve(request azurenamingtool.GenerateNameRequest) {
+	number, err := strconv.Atoi(request.ResourceInstance)
+	if err != nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := instanceKey(request)
+	if a.reserved[key] == nil {
+		a.reserved[key] = make(map[int]bool)
+	}
+	a.reserved[key][number] = true
+}
+
+// nextFreeInstance returns the lowest positive instance number that is neither used nor
+// reserved, zero-padded to the widest instance in use (at least defaultInstanceWidth).
+func nextFreeInstance(used []string, reserved map[int]bool) (string, int) {
+	width := defaultInstanceWidth
+	taken := make(map[int]bool, len(used)+len(reserved))
+
+	for number := range reserved {
+		taken[number] = true
+	}
+
+	for _, instance := range used {
+		number, err := strconv.Atoi(instance)
+		if err != nil {
+			continue
+		}
+		taken[number] = true
+		width = max(width, len(instance))
+	}
+
+	number := 1
+	for taken[number] {
+		number++
+	}
+
+	return fmt.Sprintf("%0*d", width, number), number
+}
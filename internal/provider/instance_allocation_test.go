@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNextFreeInstance(t *testing.T) {
+	testCases := map[string]struct {
+		used             []string
+		reserved         map[int]bool
+		expectedInstance string
+		expectedNumber   int
+	}{
+		"empty log": {
+			expectedInstance: "001",
+			expectedNumber:   1,
+		},
+		"fills gaps": {
+			used:             []string{"001", "003"},
+			expectedInstance: "002",
+			expectedNumber:   2,
+		},
+		"skips reserved": {
+			used:             []string{"001"},
+			reserved:         map[int]bool{2: true},
+			expectedInstance: "003",
+			expectedNumber:   3,
+		},
+		"keeps wider padding": {
+			used:             []string{"0001"},
+			expectedInstance: "0002",
+			expectedNumber:   2,
+		},
+		"ignores non-numeric instances": {
+			used:             []string{"abc", "1"},
+			expectedInstance: "002",
+			expectedNumber:   2,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			instance, number := nextFreeInstance(testCase.used, testCase.reserved)
+
+			if instance != testCase.expectedInstance {
+				t.Errorf("expected instance %q, got %q", testCase.expectedInstance, instance)
+			}
+			if number != testCase.expectedNumber {
+				t.Errorf("expected number %d, got %d", testCase.expectedNumber, number)
+			}
+		})
+	}
+}
+
+func TestGeneratedNameLogEntryComponent(t *testing.T) {
+	entry := generatedNameLogEntry{
+		Components: [][]string{
+			{"ResourceOrg", "man"},
+			{"Resource Type", "st"},
+			{"Application", "webapp"},
+			{"ResourceInstance", "002"},
+		},
+	}
+
+	if got := entry.component("organization"); got != "man" {
+		t.Errorf("expected organization %q, got %q", "man", got)
+	}
+	if got := entry.component("resource_type"); got != "st" {
+		t.Errorf("expected resource type %q, got %q", "st", got)
+	}
+	if got := entry.component("application"); got != "webapp" {
+		t.Errorf("expected application %q, got %q", "webapp", got)
+	}
+	if got := entry.component("instance"); got != "002" {
+		t.Errorf("expected instance %q, got %q", "002", got)
+	}
+	if got := entry.component("function"); got != "" {
+		t.Errorf("expected no function, got %q", got)
+	}
+}
+
+func TestGenerateNameAllocatesInstanceDuringApply(t *testing.T) {
+	ctx := context.Background()
+	tool, client := newFakeNamingTool(t)
+	r := &generateName{client: client}
+
+	config := generateNameModel{
+		Organization:       types.StringValue("alloc"),
+		ResourceType:       types.StringValue("rg"),
+		Application:        types.StringValue("webapp"),
+		Location:           types.StringValue("euw"),
+		Environment:        types.StringValue("dev"),
+		TruncationPriority: types.ListNull(types.StringType),
+		Keepers:            types.MapNull(types.StringType),
+	}
+	existing := newGenerateNameRequest(config)
+	existing.ResourceInstance = "001"
+	if _, err := client.GenerateName(existing); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	plan := config
+	plan.Instance = types.StringUnknown()
+	plan.ID = types.Int64Unknown()
+	plan.ResourceName = types.StringUnknown()
+	plan.Success = types.BoolUnknown()
+	plan.Message = types.StringUnknown()
+	plan.TruncationApplied = types.StringUnknown()
+	plan.OriginalName = types.StringUnknown()
+
+	// The instance stays unknown during plan, also when a replacement is planned twice.
+	for range 2 {
+		planned, diags := modifyPlan(t, r, nil, &config, &plan)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if !planned.Instance.IsUnknown() || !planned.ResourceName.IsUnknown() {
+			t.Errorf("expected the instance and name to be unknown, got %s and %s", planned.Instance, planned.ResourceName)
+		}
+	}
+	if tool.count() != 1 {
+		t.Errorf("expected planning to register no names, got %d names", tool.count())
+	}
+
+	// Create allocates the next free instance.
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: newPlan[generateNameModel](t, schemaResp.Schema, nil).Raw}}
+	initPrivateState(&resp.Private)
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, schemaResp.Schema, &plan)}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var state generateNameModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if state.Instance.ValueString() != "002" || state.ResourceName.ValueString() != "alloc-rg-webapp--002-euw-dev" {
+		t.Errorf("expected instance 002, got %s with name %s", state.Instance, state.ResourceName)
+	}
+}
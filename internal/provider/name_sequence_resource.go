@@ -0,0 +1,494 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &nameSequence{}
+	_ resource.ResourceWithConfigure  = &nameSequence{}
+	_ resource.ResourceWithModifyPlan = &nameSequence{}
+)
+
+// NewNameSequence is a helper function to simplify the provider implementation.
+func NewNameSequence() resource.Resource {
+	return &nameSequence{}
+}
+
+// nameSequence is the resource implementation.
+type nameSequence struct {
+	client *azurenamingtool.Client
+}
+
+// nameSequenceModel maps the resource schema data.
+type nameSequenceModel struct {
+	// Input fields for name generation.
+	Organization  types.String `tfsdk:"organization"`
+	ResourceType  types.String `tfsdk:"resource_type"`
+	Application   types.String `tfsdk:"application"`
+	Function      types.String `tfsdk:"function"`
+	Location      types.String `tfsdk:"location"`
+	Environment   types.String `tfsdk:"environment"`
+	Size          types.Int64  `tfsdk:"size"`
+	Start         types.Int64  `tfsdk:"start"`
+	InstanceWidth types.Int64  `tfsdk:"instance_width"`
+
+	// Output fields from the API.
+	Names     types.List `tfsdk:"names"`
+	IDs       types.List `tfsdk:"ids"`
+	Instances types.List `tfsdk:"instances"`
+}
+
+// Metadata returns the resource type name.
+func (r *nameSequence) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_name_sequence"
+}
+
+// Schema defines the schema for the resource.
+func (r *nameSequence) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Generates an ordered sequence of numbered Azure resource names using the Azure Naming Tool.",
+		MarkdownDescription: "Generates an ordered sequence of numbered Azure resource names using the Azure Naming Tool, " +
+			"for example for VM scale-outs or AKS node pools.\n\n" +
+			"All names share the same components and use consecutive instances starting at `start`. " +
+			"Changing `size` adds or removes names at the end of the sequence without renumbering the existing entries. " +
+			"All other input fields trigger resource replacement when changed.",
+		Attributes: map[string]schema.Attribute{
+			// Input attributes.
+			"organization": schema.StringAttribute{
+				Description:   "Organization identifier for the resource names.",
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"resource_type": schema.StringAttribute{
+				Description:   "Azure resource type short name (e.g., 'vm', 'vmss', 'aks').",
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"application": schema.StringAttribute{
+				Description:   "Application identifier for the resource names.",
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"function": schema.StringAttribute{
+				Description:   "Function or purpose identifier for the resource names.",
+				Optional:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"location": schema.StringAttribute{
+				Description:   "Azure region identifier (e.g., 'euw', 'eus').",
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"environment": schema.StringAttribute{
+				Description:   "Environment identifier (e.g., 'dev', 'test', 'prod').",
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"size": schema.Int64Attribute{
+				Description: "Number of names in the sequence. Changing it adds or removes names at the end of the sequence.",
+				Required:    true,
+				Validators:  []validator.Int64{Int64AtLeast(1)},
+			},
+			"start": schema.Int64Attribute{
+				Description:   "Instance number of the first name in the sequence. Defaults to 1.",
+				Optional:      true,
+				Validators:    []validator.Int64{Int64AtLeast(0)},
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+			"instance_width": schema.Int64Attribute{
+				Description:   "Zero-padded width of the instance numbers (e.g., 3 for '001'). Defaults to 3.",
+				Optional:      true,
+				Validators:    []validator.Int64{Int64AtLeast(1)},
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+
+			// Output attributes.
+			"names": schema.ListAttribute{
+				Description: "The generated Azure resource names, in instance order.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"ids": schema.ListAttribute{
+				Description: "The unique identifiers of the generated names in the Azure Naming Tool, in instance order.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"instances": schema.ListAttribute{
+				Description: "The instances used for the generated names, in order.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *nameSequence) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan nameSequenceModel
+
+	// Retrieve values from plan.
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names, ids, instanceValues, err := r.generateRange(plan, nil, nil, nil, int(plan.Size.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Generate Name Sequence",
+			fmt.Sprintf("An error occurred while generating the name sequence: %s\n\n"+
+				"Names generated before the error have been removed from the Azure Naming Tool.", err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.setSequence(ctx, names, ids, instanceValues)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *nameSequence) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state nameSequenceModel
+
+	// Get current state.
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The generated names are kept stable across reads, like proactnaming_generate_name.
+}
+
+// Update grows or shrinks the sequence when size changes. All other inputs require replacement.
+func (r *nameSequence) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state nameSequenceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names, ids, instanceValues, diags := state.sequence(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	size := int(plan.Size.ValueInt64())
+
+	// Remove the names at the end of the sequence when shrinking.
+	for len(ids) > size {
+		last := len(ids) - 1
+		_, err := r.client.DeleteName(azurenamingtool.DeleteGeneratedNameRequest{ID: ids[last]})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Delete Generated Name",
+				fmt.Sprintf("An error occurred while deleting the generated name %q with ID %d: %s\n\n"+
+					"This may indicate:\n"+
+					"- The entry was already deleted\n"+
+					"- Admin password is required but not configured\n"+
+					"- Network connectivity issues with the Azure Naming Tool", names[last], ids[last], err.Error()),
+			)
+			break
+		}
+		names, ids, instanceValues = names[:last], ids[:last], instanceValues[:last]
+	}
+
+	// Add names to the end of the sequence when growing.
+	if !resp.Diagnostics.HasError() && len(ids) < size {
+		var err error
+		names, ids, instanceValues, err = r.generateRange(plan, names, ids, instanceValues, size)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Generate Name Sequence",
+				fmt.Sprintf("An error occurred while extending the name sequence: %s\n\n"+
+					"Names added before the error have been removed from the Azure Naming Tool.", err.Error()),
+			)
+		}
+	}
+
+	// Save whatever was applied so that state matches the Azure Naming Tool even on partial failure.
+	// The size stays as configured; the next plan compares it with the recorded names and retries the change.
+	if len(ids) != size {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("size"),
+			"Incomplete Name Sequence",
+			fmt.Sprintf("The name sequence holds %d of the %d configured names. The next apply retries the change.", len(ids), size),
+		)
+	}
+	resp.Diagnostics.Append(plan.setSequence(ctx, names, ids, instanceValues)...)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *nameSequence) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state nameSequenceModel
+
+	// Get current state.
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names, ids, _, diags := state.sequence(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, id := range ids {
+		_, err := r.client.DeleteName(azurenamingtool.DeleteGeneratedNameRequest{ID: id})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Delete Generated Name",
+				fmt.Sprintf("An error occurred while deleting the generated name %q with ID %d: %s\n\n"+
+					"This may indicate:\n"+
+					"- The entry was already deleted\n"+
+					"- Admin password is required but not configured\n"+
+					"- Network connectivity issues with the Azure Naming Tool", names[i], id, err.Error()),
+			)
+		}
+	}
+}
+
+// ModifyPlan keeps the names of existing entries known when size changes, so that only
+// the added entries are unknown in the plan output.
+func (r *nameSequence) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Skip for destroy operations.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state nameSequenceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owner, diags := planOwnerID(ctx, req, resp)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fail the plan if another resource of this configuration plans the same instances.
+	// Existing instances are registered as well, so that new resources cannot reuse them.
+	r.checkDuplicates(&resp.Diagnostics, plan, owner)
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The RequiresReplace paths of the attribute plan modifiers are not visible here,
+	// so a replacement is detected by comparing the inputs that require one.
+	if plan.replaces(state) || plan.Size.IsUnknown() {
+		return
+	}
+
+	names, ids, instanceValues, diags := state.sequence(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The names are compared rather than the recorded size, so that a change that was only
+	// partially applied is retried.
+	size := int(plan.Size.ValueInt64())
+	if size == len(ids) {
+		return
+	}
+	kept := min(size, len(ids))
+
+	nameValues := make([]attr.Value, size)
+	idValues := make([]attr.Value, size)
+	instanceAttrs := make([]attr.Value, size)
+	for i := range size {
+		if i < kept {
+			nameValues[i] = types.StringValue(names[i])
+			idValues[i] = types.Int64Value(ids[i])
+			instanceAttrs[i] = types.StringValue(instanceValues[i])
+			continue
+		}
+		nameValues[i] = types.StringUnknown()
+		idValues[i] = types.Int64Unknown()
+		instanceAttrs[i] = types.StringValue(plan.instance(i))
+	}
+
+	plan.Names = types.ListValueMust(types.StringType, nameValues)
+	plan.IDs = types.ListValueMust(types.Int64Type, idValues)
+	plan.Instances = types.ListValueMust(types.StringType, instanceAttrs)
+
+	diags = resp.Plan.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// checkDuplicates registers the planned entries with the plan registry and adds an error
+// diagnostic for entries planned by other resources too.
+func (r *nameSequence) checkDuplicates(diags *diag.Diagnostics, plan nameSequenceModel, owner string) {
+	for _, value := range []attr.Value{plan.Organization, plan.ResourceType, plan.Application, plan.Function,
+		plan.Location, plan.Environment, plan.Size, plan.Start, plan.InstanceWidth} {
+		if value.IsUnknown() {
+			return
+		}
+	}
+
+	for i := range int(plan.Size.ValueInt64()) {
+		request := plan.request(i)
+		plannedNames.checkDuplicate(diags, request, "", planOwner{id: owner, description: describeRequest("proactnaming_name_sequence", request)})
+	}
+}
+
+// generateRange generates the names from the current length of the sequence up to size.
+// On error, the names generated by this call are removed again and the original slices are returned.
+func (r *nameSequence) generateRange(plan nameSequenceModel, names []string, ids []int64, instanceValues []string, size int) ([]string, []int64, []string, error) {
+	existing := len(ids)
+
+	for i := existing; i < size; i++ {
+		request := plan.request(i)
+		instances.reserve(request)
+
+		generateResponse, err := generationClient(r.client).GenerateName(request)
+		if err == nil && !generateResponse.Success {
+			err = fmt.Errorf("instance %s: %s", request.ResourceInstance, generateResponse.Message)
+		}
+		if err != nil {
+			for _, id := range ids[existing:] {
+				_, _ = r.client.DeleteName(azurenamingtool.DeleteGeneratedNameRequest{ID: id})
+			}
+			return names[:existing], ids[:existing], instanceValues[:existing], err
+		}
+
+		names = append(names, generateResponse.ResourceName)
+		ids = append(ids, generateResponse.ResourceNameDetails.ID)
+		instanceValues = append(instanceValues, request.ResourceInstance)
+	}
+
+	return names, ids, instanceValues, nil
+}
+
+// replaces reports whether any input that requires replacement differs between the models.
+// It must list every attribute of the schema with the RequiresReplace plan modifier.
+func (m nameSequenceModel) replaces(state nameSequenceModel) bool {
+	return !m.Organization.Equal(state.Organization) || !m.ResourceType.Equal(state.ResourceType) ||
+		!m.Application.Equal(state.Application) || !m.Function.Equal(state.Function) ||
+		!m.Location.Equal(state.Location) || !m.Environment.Equal(state.Environment) ||
+		!m.Start.Equal(state.Start) || !m.InstanceWidth.Equal(state.InstanceWidth)
+}
+
+// instance returns the zero-padded instance of the entry at the given index of the sequence.
+func (m nameSequenceModel) instance(index int) string {
+	start := int64(1)
+	if !m.Start.IsNull() {
+		start = m.Start.ValueInt64()
+	}
+
+	width := int64(defaultInstanceWidth)
+	if !m.InstanceWidth.IsNull() {
+		width = m.InstanceWidth.ValueInt64()
+	}
+
+	return fmt.Sprintf("%0*d", width, start+int64(index))
+}
+
+// request builds the Azure Naming Tool request for the entry at the given index of the sequence.
+func (m nameSequenceModel) request(index int) azurenamingtool.GenerateNameRequest {
+	return azurenamingtool.GenerateNameRequest{
+		ResourceOrg:         m.Organization.ValueString(),
+		ResourceType:        m.ResourceType.ValueString(),
+		ResourceEnvironment: m.Environment.ValueString(),
+		ResourceFunction:    m.Function.ValueString(),
+		ResourceInstance:    m.instance(index),
+		ResourceLocation:    m.Location.ValueString(),
+		CustomComponents: azurenamingtool.GenerateNameRequestCustomComponents{
+			Application: m.Application.ValueString(),
+		},
+	}
+}
+
+// sequence returns the names, IDs and instances stored in the model.
+func (m nameSequenceModel) sequence(ctx context.Context) ([]string, []int64, []string, diag.Diagnostics) {
+	var names, instanceValues []string
+	var ids []int64
+	var diags diag.Diagnostics
+
+	diags.Append(m.Names.ElementsAs(ctx, &names, false)...)
+	diags.Append(m.IDs.ElementsAs(ctx, &ids, false)...)
+	diags.Append(m.Instances.ElementsAs(ctx, &instanceValues, false)...)
+
+	return names, ids, instanceValues, diags
+}
+
+// setSequence stores the names, IDs and instances in the model.
+func (m *nameSequenceModel) setSequence(ctx context.Context, names []string, ids []int64, instanceValues []string) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+
+	m.Names, d = types.ListValueFrom(ctx, types.StringType, names)
+	diags.Append(d...)
+	m.IDs, d = types.ListValueFrom(ctx, types.Int64Type, ids)
+	diags.Append(d...)
+	m.Instances, d = types.ListValueFrom(ctx, types.StringType, instanceValues)
+	diags.Append(d...)
+
+	return diags
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *nameSequence) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform.
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*azurenamingtool.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *azurenamingtool.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
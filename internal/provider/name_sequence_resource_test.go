@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNameSequenceGenerateRange(t *testing.T) {
+	tool, client := newFakeNamingTool(t)
+	r := &nameSequence{client: client}
+
+	plan := nameSequenceModel{
+		Organization:  types.StringValue("man"),
+		ResourceType:  types.StringValue("vm"),
+		Application:   types.StringValue("webapp"),
+		Function:      types.StringValue("web"),
+		Location:      types.StringValue("euw"),
+		Environment:   types.StringValue("dev"),
+		Start:         types.Int64Value(9),
+		InstanceWidth: types.Int64Null(),
+	}
+
+	names, ids, instanceValues, err := r.generateRange(plan, nil, nil, nil, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(ids) != 2 || instanceValues[0] != "009" || instanceValues[1] != "010" {
+		t.Fatalf("expected instances 009 and 010, got %v", instanceValues)
+	}
+	if names[1] != "man-vm-webapp-web-010-euw-dev" {
+		t.Errorf("unexpected name %q", names[1])
+	}
+
+	// Growing the sequence keeps the existing entries and rolls back on failure.
+	tool.failInstance = "012"
+	names, ids, instanceValues, err = r.generateRange(plan, names, ids, instanceValues, 4)
+	if err == nil {
+		t.Fatal("expected an error when the tool rejects an instance")
+	}
+	if len(ids) != 2 || len(names) != 2 || len(instanceValues) != 2 {
+		t.Errorf("expected the original sequence to be returned, got %v", instanceValues)
+	}
+	if tool.count() != 2 {
+		t.Errorf("expected the names added before the error to be removed, got %d names", tool.count())
+	}
+}
+
+func TestNameSequenceReplacementChecksDuplicates(t *testing.T) {
+	resetPlannedNames(t)
+
+	state := nameSequenceModel{
+		Organization:  types.StringValue("man"),
+		ResourceType:  types.StringValue("vm"),
+		Application:   types.StringValue("webapp"),
+		Function:      types.StringValue("web"),
+		Location:      types.StringValue("euw"),
+		Environment:   types.StringValue("dev"),
+		Size:          types.Int64Value(1),
+		Start:         types.Int64Null(),
+		InstanceWidth: types.Int64Null(),
+		Names:         types.ListValueMust(types.StringType, []attr.Value{types.StringValue("man-vm-webapp-web-001-euw-dev")}),
+		IDs:           types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(1)}),
+		Instances:     types.ListValueMust(types.StringType, []attr.Value{types.StringValue("001")}),
+	}
+
+	// Another resource of the configuration plans the sequence of the production environment.
+	var diags diag.Diagnostics
+	other := state
+	other.Environment = types.StringValue("prd")
+	plannedNames.checkDuplicate(&diags, other.request(0), "", planOwner{id: "other", description: "other"})
+
+	// An unchanged sequence does not clash with it.
+	if _, diags := modifyPlan(t, &nameSequence{}, &state, &state, &state); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// Changing one component replaces the sequence, which then plans the same names.
+	plan := other
+	plan.Names = types.ListUnknown(types.StringType)
+	plan.IDs = types.ListUnknown(types.Int64Type)
+	plan.Instances = types.ListUnknown(types.StringType)
+	if _, diags := modifyPlan(t, &nameSequence{}, &state, &plan, &plan); !diags.HasError() {
+		t.Error("expected an error for a replacement that plans the names of another resource")
+	}
+}
+
+func TestNameSequenceUpdateKeepsConfiguredSize(t *testing.T) {
+	resetPlannedNames(t)
+
+	ctx := context.Background()
+	tool, client := newFakeNamingTool(t)
+	r := &nameSequence{client: client}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	state := nameSequenceModel{
+		Organization:  types.StringValue("man"),
+		ResourceType:  types.StringValue("vm"),
+		Application:   types.StringValue("webapp"),
+		Function:      types.StringValue("web"),
+		Location:      types.StringValue("euw"),
+		Environment:   types.StringValue("dev"),
+		Size:          types.Int64Value(1),
+		Start:         types.Int64Null(),
+		InstanceWidth: types.Int64Null(),
+	}
+	names, ids, instanceValues, err := r.generateRange(state, nil, nil, nil, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diags := state.setSequence(ctx, names, ids, instanceValues); diags.HasError() {
+		t.Fatalf("unable to set the sequence: %v", diags)
+	}
+
+	config := state
+	config.Size = types.Int64Value(3)
+	config.Names = types.ListUnknown(types.StringType)
+	config.IDs = types.ListUnknown(types.Int64Type)
+	config.Instances = types.ListUnknown(types.StringType)
+
+	update := func() (nameSequenceModel, resource.UpdateResponse) {
+		t.Helper()
+
+		// Every run plans in a new provider process.
+		resetPlannedNames(t)
+
+		plan, diags := modifyPlan(t, r, &state, &config, &config)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if len(plan.Names.Elements()) != 3 || plan.Names.Elements()[0].IsUnknown() || !plan.Names.Elements()[2].IsUnknown() {
+			t.Fatalf("expected the missing names to be planned, got %s", plan.Names)
+		}
+
+		updateResp := resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: newPlan[nameSequenceModel](t, s, nil).Raw}}
+		r.Update(ctx, resource.UpdateRequest{Plan: newPlan(t, s, plan), State: tfsdk.State{Schema: s, Raw: newPlan(t, s, &state).Raw}}, &updateResp)
+
+		var updated nameSequenceModel
+		updateResp.State.Get(ctx, &updated)
+		return updated, updateResp
+	}
+
+	// A failing extension keeps the configured size and reports the shortfall.
+	tool.failInstance = "003"
+	updated, updateResp := update()
+	if !updateResp.Diagnostics.HasError() || updateResp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected an error and a warning about the shortfall, got %v", updateResp.Diagnostics)
+	}
+	if updated.Size.ValueInt64() != 3 || len(updated.IDs.Elements()) != 1 {
+		t.Errorf("expected the configured size with the previous names, got size %s and %s", updated.Size, updated.Names)
+	}
+
+	// The next apply retries the change.
+	tool.failInstance = ""
+	state = updated
+	updated, updateResp = update()
+	if updateResp.Diagnostics.HasError() || updateResp.Diagnostics.WarningsCount() != 0 {
+		t.Fatalf("unexpected diagnostics: %v", updateResp.Diagnostics)
+	}
+	if len(updated.IDs.Elements()) != 3 || tool.count() != 3 {
+		t.Errorf("expected 3 names, got %s and %d names", updated.Names, tool.count())
+	}
+}
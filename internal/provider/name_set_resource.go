@@ -0,0 +1,660 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &nameSet{}
+	_ resource.ResourceWithConfigure  = &nameSet{}
+	_ resource.ResourceWithModifyPlan = &nameSet{}
+)
+
+// NewNameSet is a helper function to simplify the provider implementation.
+func NewNameSet() resource.Resource {
+	return &nameSet{}
+}
+
+// nameSet is the resource implementation.
+type nameSet struct {
+	client *azurenamingtool.Client
+}
+
+// nameSetModel maps the resource schema data.
+type nameSetModel struct {
+	// Shared input fields for name generation.
+	Organization types.String `tfsdk:"organization"`
+	Application  types.String `tfsdk:"application"`
+	Function     types.String `tfsdk:"function"`
+	Instance     types.String `tfsdk:"instance"`
+	Location     types.String `tfsdk:"location"`
+	Environment  types.String `tfsdk:"environment"`
+
+	// Logical keys mapped to resource types and per-key overrides.
+	Resources types.Map `tfsdk:"resources"`
+
+	// Output fields from the API.
+	Names types.Map `tfsdk:"names"`
+	IDs   types.Map `tfsdk:"ids"`
+}
+
+// nameSetResourceModel maps an entry of the resources attribute.
+type nameSetResourceModel struct {
+	ResourceType types.String `tfsdk:"resource_type"`
+	Organization types.String `tfsdk:"organization"`
+	Application  types.String `tfsdk:"application"`
+	Function     types.String `tfsdk:"function"`
+	Instance     types.String `tfsdk:"instance"`
+	Location     types.String `tfsdk:"location"`
+	Environment  types.String `tfsdk:"environment"`
+}
+
+// nameSetResourceTypes are the attribute types of nameSetResourceModel.
+var nameSetResourceTypes = map[string]attr.Type{
+	"resource_type": types.StringType,
+	"organization":  types.StringType,
+	"application":   types.StringType,
+	"function":      types.StringType,
+	"instance":      types.StringType,
+	"location":      types.StringType,
+	"environment":   types.StringType,
+}
+
+// Metadata returns the resource type name.
+func (r *nameSet) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_name_set"
+}
+
+// Schema defines the schema for the resource.
+func (r *nameSet) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Generates a set of Azure resource names sharing the same components using the Azure Naming Tool.",
+		MarkdownDescription: "Generates a set of Azure resource names sharing the same components using the Azure Naming Tool, " +
+			"for example all resource group, network, storage and key vault names of an application.\n\n" +
+			"Each entry of `resources` maps a logical key to a resource type and optional per-key overrides of the shared components. " +
+			"Names are generated concurrently. Changing an entry only regenerates the name of that entry; " +
+			"changing a shared component regenerates every entry that uses it.",
+		Attributes: map[string]schema.Attribute{
+			// Shared input attributes.
+			"organization": schema.StringAttribute{
+				Description: "Organization identifier shared by all names.",
+				Required:    true,
+			},
+			"application": schema.StringAttribute{
+				Description: "Application identifier shared by all names.",
+				Required:    true,
+			},
+			"function": schema.StringAttribute{
+				Description: "Function or purpose identifier shared by all names.",
+				Optional:    true,
+			},
+			"instance": schema.StringAttribute{
+				Description: "Instance number or identifier shared by all names. Defaults to '001'.",
+				Optional:    true,
+			},
+			"location": schema.StringAttribute{
+				Description: "Azure region identifier shared by all names (e.g., 'euw', 'eus').",
+				Required:    true,
+			},
+			"environment": schema.StringAttribute{
+				Description: "Environment identifier shared by all names (e.g., 'dev', 'test', 'prod').",
+				Required:    true,
+			},
+			"resources": schema.MapNestedAttribute{
+				Description: "Logical keys mapped to the resource type and per-key overrides of the shared components.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"resource_type": schema.StringAttribute{
+							Description: "Azure resource type short name (e.g., 'rg', 'st', 'vm').",
+							Required:    true,
+						},
+						"organization": schema.StringAttribute{
+							Description: "Overrides the shared organization identifier for this entry.",
+							Optional:    true,
+						},
+						"application": schema.StringAttribute{
+							Description: "Overrides the shared application identifier for this entry.",
+							Optional:    true,
+						},
+						"function": schema.StringAttribute{
+							Description: "Overrides the shared function identifier for this entry.",
+							Optional:    true,
+						},
+						"instance": schema.StringAttribute{
+							Description: "Overrides the shared instance for this entry.",
+							Optional:    true,
+						},
+						"location": schema.StringAttribute{
+							Description: "Overrides the shared location identifier for this entry.",
+							Optional:    true,
+						},
+						"environment": schema.StringAttribute{
+							Description: "Overrides the shared environment identifier for this entry.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+
+			// Output attributes.
+			"names": schema.MapAttribute{
+				Description: "The generated Azure resource names, keyed by the logical keys of resources.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"ids": schema.MapAttribute{
+				Description: "The unique identifiers of the generated names in the Azure Naming Tool, keyed by the logical keys of resources.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *nameSet) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan nameSetModel
+
+	// Retrieve values from plan.
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries, diags := plan.entries(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	responses, err := r.generateAll(plan.requests(entries))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Generate Name Set",
+			fmt.Sprintf("An error occurred while generating the name set: %s\n\n"+
+				"Names generated before the error have been removed from the Azure Naming Tool.", err.Error()),
+		)
+		return
+	}
+
+	names := make(map[string]string, len(responses))
+	ids := make(map[string]int64, len(responses))
+	for key, generateResponse := range responses {
+		names[key] = generateResponse.ResourceName
+		ids[key] = generateResponse.ResourceNameDetails.ID
+	}
+	plan.setNames(names, ids)
+
+	// Set state to fully populated data.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *nameSet) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state nameSetModel
+
+	// Get current state.
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The generated names are kept stable across reads, like proactnaming_generate_name.
+}
+
+// Update generates the names of new and changed entries and then removes the names of deleted
+// and changed entries. Unchanged entries keep their names. Previous names are only removed once
+// all new names have been generated, so that a failure leaves the previous names in place.
+func (r *nameSet) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state nameSetModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, diags := plan.entries(ctx)
+	resp.Diagnostics.Append(diags...)
+	current, diags := state.entries(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names, ids := state.names()
+	previous := state.requests(current)
+	changed := make(map[string]azurenamingtool.GenerateNameRequest)
+
+	for key, request := range plan.requests(planned) {
+		if old, ok := previous[key]; ok && old == request {
+			continue
+		}
+		changed[key] = request
+	}
+
+	responses, err := r.generateAll(changed)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Generate Name Set",
+			fmt.Sprintf("An error occurred while updating the name set: %s\n\n"+
+				"Names generated before the error have been removed from the Azure Naming Tool "+
+				"and the previous names are kept.", err.Error()),
+		)
+
+		diags = resp.State.Set(ctx, state)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	// Remove the previous names of deleted and changed entries. The names of entries that were
+	// deleted before, but could not be removed, are kept in state and retried here.
+	for _, key := range sortedKeys(ids) {
+		_, kept := planned[key]
+		if _, ok := changed[key]; kept && !ok {
+			continue
+		}
+
+		name, id := names[key], ids[key]
+		_, err := r.client.DeleteName(azurenamingtool.DeleteGeneratedNameRequest{ID: id})
+		if err != nil {
+			// The new name of a changed entry is issued already, so it replaces the previous name in
+			// state either way. Only the names of deleted entries can be kept for the next apply.
+			consequence := "The name is kept in state and removed by the next apply."
+			if kept {
+				consequence = "The new name of the entry is kept in state. Remove the previous name from the Azure Naming Tool manually."
+				delete(names, key)
+				delete(ids, key)
+			}
+			resp.Diagnostics.AddError(
+				"Unable to Delete Generated Name",
+				fmt.Sprintf("An error occurred while deleting the generated name %q with ID %d for key %q: %s\n\n"+
+					"This may indicate:\n"+
+					"- The entry was already deleted\n"+
+					"- Admin password is required but not configured\n"+
+					"- Network connectivity issues with the Azure Naming Tool\n\n"+
+					"%s", name, id, key, err.Error(), consequence),
+			)
+			continue
+		}
+		delete(names, key)
+		delete(ids, key)
+	}
+
+	for key, generateResponse := range responses {
+		names[key] = generateResponse.ResourceName
+		ids[key] = generateResponse.ResourceNameDetails.ID
+	}
+
+	plan.setNames(names, ids)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *nameSet) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state nameSetModel
+
+	// Get current state.
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names, ids := state.names()
+	for _, key := range sortedKeys(ids) {
+		_, err := r.client.DeleteName(azurenamingtool.DeleteGeneratedNameRequest{ID: ids[key]})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Delete Generated Name",
+				fmt.Sprintf("An error occurred while deleting the generated name %q with ID %d for key %q: %s\n\n"+
+					"This may indicate:\n"+
+					"- The entry was already deleted\n"+
+					"- Admin password is required but not configured\n"+
+					"- Network connectivity issues with the Azure Naming Tool", names[key], ids[key], key, err.Error()),
+			)
+		}
+	}
+}
+
+// ModifyPlan previews the names of new and changed entries, and keeps the names of
+// unchanged entries, so that the plan only shows differences for the affected keys.
+func (r *nameSet) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Skip for destroy operations.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan nameSetModel
+
+	// Get the planned configuration.
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Skip if the client is not available (shouldn't happen, but safety check).
+	if r.client == nil {
+		return
+	}
+
+	// The keys are not known yet, so neither are the names.
+	if plan.Resources.IsUnknown() {
+		plan.Names = types.MapUnknown(types.StringType)
+		plan.IDs = types.MapUnknown(types.Int64Type)
+
+		diags = resp.Plan.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	entries, diags := plan.entries(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var previous map[string]azurenamingtool.GenerateNameRequest
+	var stateNames map[string]string
+	var stateIDs map[string]int64
+	if !req.State.Raw.IsNull() {
+		var state nameSetModel
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		current, diags := state.entries(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		previous = state.requests(current)
+		stateNames, stateIDs = state.names()
+	}
+
+	nameValues := make(map[string]attr.Value, len(entries))
+	idValues := make(map[string]attr.Value, len(entries))
+	previews := make(map[string]azurenamingtool.GenerateNameRequest)
+
+	requests := plan.requests(entries)
+	for key, entry := range entries {
+		request, known := requests[key], plan.known(entry)
+
+		if old, ok := previous[key]; ok && known && old == request {
+			nameValues[key] = types.StringValue(stateNames[key])
+			idValues[key] = types.Int64Value(stateIDs[key])
+			continue
+		}
+
+		nameValues[key] = types.StringUnknown()
+		idValues[key] = types.Int64Unknown()
+		if known {
+			previews[key] = request
+		}
+	}
+
+	// Generate previews of the new names and clean up the preview entries immediately.
+	responses, err := r.generateAll(previews)
+	if err != nil {
+		// Fail the plan if we can't reach the API - this indicates a configuration problem.
+		resp.Diagnostics.AddError(
+			"Unable to Generate Name Preview",
+			fmt.Sprintf("An error occurred while generating the name set preview: %s\n\n"+
+				"Please verify:\n"+
+				"- Azure Naming Tool is accessible at the configured host\n"+
+				"- API key has sufficient permissions\n"+
+				"- Input parameters match your naming tool configuration", err.Error()),
+		)
+		return
+	}
+	for key, generateResponse := range responses {
+		nameValues[key] = types.StringValue(generateResponse.ResourceName)
+		if generateResponse.ResourceNameDetails.ID != 0 {
+			// Ignore errors - this is cleanup for preview entries.
+			_, _ = r.client.DeleteName(azurenamingtool.DeleteGeneratedNameRequest{
+				ID: generateResponse.ResourceNameDetails.ID,
+			})
+		}
+	}
+
+	// Fail the plan if another entry or resource of this configuration would receive the same name.
+	// Unchanged entries are registered as well, so that new entries and resources cannot reuse their names.
+	owner, diags := planOwnerID(ctx, req, resp)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, key := range sortedKeys(entries) {
+		if !plan.known(entries[key]) {
+			continue
+		}
+		name, _ := nameValues[key].(types.String)
+		plannedNames.checkDuplicate(&resp.Diagnostics, requests[key], name.ValueString(), planOwner{
+			id:          owner + "/" + key,
+			description: describeRequest(fmt.Sprintf("proactnaming_name_set entry %q", key), requests[key]),
+		})
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Names = types.MapValueMust(types.StringType, nameValues)
+	plan.IDs = types.MapValueMust(types.Int64Type, idValues)
+
+	diags = resp.Plan.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// generateAll generates the names of all requests concurrently. If any request fails,
+// the names generated by this call are removed again and an error is returned.
+func (r *nameSet) generateAll(requests map[string]azurenamingtool.GenerateNameRequest) (map[string]*azurenamingtool.GenerateNameResponse, error) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var errs []error
+	responses := make(map[string]*azurenamingtool.GenerateNameResponse, len(requests))
+
+	for key, request := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			generateResponse, err := generationClient(r.client).GenerateName(request)
+			if err == nil && !generateResponse.Success {
+				err = errors.New(generateResponse.Message)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+				return
+			}
+			responses[key] = generateResponse
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		for _, generateResponse := range responses {
+			if generateResponse.ResourceNameDetails.ID != 0 {
+				_, _ = r.client.DeleteName(azurenamingtool.DeleteGeneratedNameRequest{
+					ID: generateResponse.ResourceNameDetails.ID,
+				})
+			}
+		}
+		return nil, errors.Join(errs...)
+	}
+
+	return responses, nil
+}
+
+// entries returns the entries of the resources attribute. An entry that is not known yet is
+// returned with unknown components, so that its name is planned as unknown.
+func (m nameSetModel) entries(ctx context.Context) (map[string]nameSetResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	entries := make(map[string]nameSetResourceModel, len(m.Resources.Elements()))
+
+	for key, value := range m.Resources.Elements() {
+		object, ok := value.(types.Object)
+		if !ok || object.IsUnknown() {
+			entries[key] = nameSetResourceModel{ResourceType: types.StringUnknown()}
+			continue
+		}
+
+		var entry nameSetResourceModel
+		diags.Append(object.As(ctx, &entry, basetypes.ObjectAsOptions{})...)
+		entries[key] = entry
+	}
+
+	return entries, diags
+}
+
+// requests builds the Azure Naming Tool request of every entry, applying the per-key overrides.
+func (m nameSetModel) requests(entries map[string]nameSetResourceModel) map[string]azurenamingtool.GenerateNameRequest {
+	requests := make(map[string]azurenamingtool.GenerateNameRequest, len(entries))
+
+	for key, entry := range entries {
+		instance := overrideValue(entry.Instance, m.Instance)
+		if instance == "" {
+			instance = fmt.Sprintf("%0*d", defaultInstanceWidth, 1)
+		}
+
+		requests[key] = azurenamingtool.GenerateNameRequest{
+			ResourceOrg:         overrideValue(entry.Organization, m.Organization),
+			ResourceType:        entry.ResourceType.ValueString(),
+			ResourceEnvironment: overrideValue(entry.Environment, m.Environment),
+			ResourceFunction:    overrideValue(entry.Function, m.Function),
+			ResourceInstance:    instance,
+			ResourceLocation:    overrideValue(entry.Location, m.Location),
+			CustomComponents: azurenamingtool.GenerateNameRequestCustomComponents{
+				Application: overrideValue(entry.Application, m.Application),
+			},
+		}
+	}
+
+	return requests
+}
+
+// known reports whether every component of the entry is known.
+func (m nameSetModel) known(entry nameSetResourceModel) bool {
+	for _, value := range []types.String{
+		entry.ResourceType,
+		effective(entry.Organization, m.Organization),
+		effective(entry.Application, m.Application),
+		effective(entry.Function, m.Function),
+		effective(entry.Instance, m.Instance),
+		effective(entry.Location, m.Location),
+		effective(entry.Environment, m.Environment),
+	} {
+		if value.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// names returns the generated names and IDs stored in the model.
+func (m nameSetModel) names() (map[string]string, map[string]int64) {
+	names := make(map[string]string)
+	ids := make(map[string]int64)
+
+	for key, value := range m.Names.Elements() {
+		if name, ok := value.(types.String); ok && !name.IsNull() && !name.IsUnknown() {
+			names[key] = name.ValueString()
+		}
+	}
+	for key, value := range m.IDs.Elements() {
+		if id, ok := value.(types.Int64); ok && !id.IsNull() && !id.IsUnknown() {
+			ids[key] = id.ValueInt64()
+		}
+	}
+
+	return names, ids
+}
+
+// setNames stores the generated names and IDs in the model.
+func (m *nameSetModel) setNames(names map[string]string, ids map[string]int64) {
+	nameValues := make(map[string]attr.Value, len(names))
+	for key, name := range names {
+		nameValues[key] = types.StringValue(name)
+	}
+	idValues := make(map[string]attr.Value, len(ids))
+	for key, id := range ids {
+		idValues[key] = types.Int64Value(id)
+	}
+
+	m.Names = types.MapValueMust(types.StringType, nameValues)
+	m.IDs = types.MapValueMust(types.Int64Type, idValues)
+}
+
+// effective returns the override when it is set, otherwise the shared value.
+func effective(override, shared types.String) types.String {
+	if !override.IsNull() {
+		return override
+	}
+	return shared
+}
+
+// overrideValue returns the string value of the override when it is set, otherwise of the shared value.
+func overrideValue(override, shared types.String) string {
+	return effective(override, shared).ValueString()
+}
+
+// sortedKeys returns the keys of the map in lexical order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *nameSet) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform.
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*azurenamingtool.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *azurenamingtool.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
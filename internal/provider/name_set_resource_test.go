@@ -0,0 +1,257 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// setEntries stores the entries in the resources attribute of the model.
+func (m *nameSetModel) setEntries(ctx context.Context, entries map[string]nameSetResourceModel) diag.Diagnostics {
+	resources, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: nameSetResourceTypes}, entries)
+	m.Resources = resources
+	return diags
+}
+
+func TestNameSetRequests(t *testing.T) {
+	model := nameSetModel{
+		Organization: types.StringValue("man"),
+		Application:  types.StringValue("webapp"),
+		Function:     types.StringNull(),
+		Instance:     types.StringNull(),
+		Location:     types.StringValue("euw"),
+		Environment:  types.StringValue("dev"),
+	}
+
+	requests := model.requests(map[string]nameSetResourceModel{
+		"rg": {
+			ResourceType: types.StringValue("rg"),
+		},
+		"st": {
+			ResourceType: types.StringValue("st"),
+			Function:     types.StringValue("data"),
+			Instance:     types.StringValue("002"),
+			Location:     types.StringValue("eus"),
+		},
+	})
+
+	if got := requests["rg"]; got.ResourceInstance != "001" || got.ResourceLocation != "euw" || got.ResourceFunction != "" {
+		t.Errorf("expected shared components and default instance for rg, got %+v", got)
+	}
+	if got := requests["st"]; got.ResourceInstance != "002" || got.ResourceLocation != "eus" || got.ResourceFunction != "data" ||
+		got.CustomComponents.Application != "webapp" {
+		t.Errorf("expected overrides to be applied for st, got %+v", got)
+	}
+}
+
+func TestNameSetGenerateAll(t *testing.T) {
+	tool, client := newFakeNamingTool(t)
+	r := &nameSet{client: client}
+
+	model := nameSetModel{
+		Organization: types.StringValue("man"),
+		Application:  types.StringValue("webapp"),
+		Location:     types.StringValue("euw"),
+		Environment:  types.StringValue("dev"),
+	}
+	entries := map[string]nameSetResourceModel{
+		"rg":  {ResourceType: types.StringValue("rg")},
+		"kv":  {ResourceType: types.StringValue("kv")},
+		"app": {ResourceType: types.StringValue("app"), Instance: types.StringValue("009")},
+	}
+
+	responses, err := r.generateAll(model.requests(entries))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(responses) != 3 || tool.count() != 3 {
+		t.Fatalf("expected 3 generated names, got %d responses and %d names", len(responses), tool.count())
+	}
+
+	// A failing entry rolls back every name generated by the same call.
+	tool.failInstance = "009"
+	model.Organization = types.StringValue("abc")
+	if _, err := r.generateAll(model.requests(entries)); err == nil {
+		t.Fatal("expected an error when the tool rejects an entry")
+	}
+	if tool.count() != 3 {
+		t.Errorf("expected the names generated before the error to be removed, got %d names", tool.count())
+	}
+
+	// A name the tool rejects, such as an over-long one, fails the call as well.
+	tool.failInstance = ""
+	tool.resourceTypes[1] = azurenamingtool.ResourceTypes{ID: 1, ShortName: "kv", LengthMax: "10"}
+	if _, err := r.generateAll(model.requests(entries)); err == nil || !strings.Contains(err.Error(), "maximum length") {
+		t.Fatalf("expected an error for the over-long name, got %v", err)
+	}
+	if tool.count() != 3 {
+		t.Errorf("expected the names generated before the error to be removed, got %d names", tool.count())
+	}
+}
+
+func TestNameSetUpdateReplacesChangedEntries(t *testing.T) {
+	resetPlannedNames(t)
+
+	ctx := context.Background()
+	tool, client := newFakeNamingTool(t)
+	r := &nameSet{client: client}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	config := nameSetModel{
+		Organization: types.StringValue("man"),
+		Application:  types.StringValue("webapp"),
+		Function:     types.StringNull(),
+		Instance:     types.StringNull(),
+		Location:     types.StringValue("euw"),
+		Environment:  types.StringValue("dev"),
+		Names:        types.MapUnknown(types.StringType),
+		IDs:          types.MapUnknown(types.Int64Type),
+	}
+	setEntries := func(model *nameSetModel, instance string) {
+		entries := map[string]nameSetResourceModel{
+			"rg": {ResourceType: types.StringValue("rg"), Organization: types.StringNull(), Application: types.StringNull(),
+				Function: types.StringNull(), Instance: types.StringNull(), Location: types.StringNull(), Environment: types.StringNull()},
+			"st": {ResourceType: types.StringValue("st"), Organization: types.StringNull(), Application: types.StringNull(),
+				Function: types.StringNull(), Instance: types.StringValue(instance), Location: types.StringNull(), Environment: types.StringNull()},
+		}
+		if diags := model.setEntries(ctx, entries); diags.HasError() {
+			t.Fatalf("unable to set entries: %v", diags)
+		}
+	}
+	setEntries(&config, "001")
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: newPlan[nameSetModel](t, s, nil).Raw}}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &config)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+	}
+	var state nameSetModel
+	createResp.State.Get(ctx, &state)
+	names, ids := state.names()
+
+	update := func(instance, failInstance string, failDeletes bool) (nameSetModel, resource.UpdateResponse) {
+		t.Helper()
+		changed := config
+		setEntries(&changed, instance)
+
+		// Every run plans in a new provider process.
+		resetPlannedNames(t)
+
+		plan, diags := modifyPlan(t, r, &state, &changed, &changed)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if !plan.Names.Elements()["rg"].Equal(types.StringValue(names["rg"])) || plan.Names.Elements()["st"].Equal(types.StringValue(names["st"])) {
+			t.Fatalf("expected only the changed entry to be planned, got %s", plan.Names)
+		}
+
+		tool.failInstance, tool.failDeletes = failInstance, failDeletes
+		updateResp := resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: newPlan[nameSetModel](t, s, nil).Raw}}
+		r.Update(ctx, resource.UpdateRequest{Plan: newPlan(t, s, plan), State: tfsdk.State{Schema: s, Raw: newPlan(t, s, &state).Raw}}, &updateResp)
+
+		var updated nameSetModel
+		updateResp.State.Get(ctx, &updated)
+		return updated, updateResp
+	}
+
+	// A failing replacement keeps the previous names.
+	updated, updateResp := update("002", "002", false)
+	if !updateResp.Diagnostics.HasError() {
+		t.Fatal("expected an error when the tool rejects the replacement")
+	}
+	if got, _ := updated.names(); got["st"] != names["st"] || tool.count() != 2 {
+		t.Errorf("expected the previous name to be kept, got %v and %d names", got, tool.count())
+	}
+
+	// Changing the instance of one entry only replaces the name of that entry.
+	updated, updateResp = update("003", "", false)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", updateResp.Diagnostics)
+	}
+	updatedNames, updatedIDs := updated.names()
+	if updatedNames["rg"] != names["rg"] || updatedIDs["rg"] != ids["rg"] {
+		t.Errorf("expected the unchanged entry to keep its name, got %v", updatedNames)
+	}
+	if updatedNames["st"] != "man-st-webapp--003-euw-dev" || updatedIDs["st"] == ids["st"] {
+		t.Errorf("expected the changed entry to be replaced, got %v", updatedNames)
+	}
+	if tool.count() != 2 {
+		t.Errorf("expected the previous name of the changed entry to be removed, got %d names", tool.count())
+	}
+
+	// A previous name that cannot be removed keeps the configured entries and the new name in state.
+	state, names, ids = updated, updatedNames, updatedIDs
+	updated, updateResp = update("004", "", true)
+	if !updateResp.Diagnostics.HasError() {
+		t.Fatal("expected an error when the previous name cannot be removed")
+	}
+	configured := config
+	setEntries(&configured, "004")
+	if !updated.Resources.Equal(configured.Resources) {
+		t.Errorf("expected the configured entries to be kept, got %s", updated.Resources)
+	}
+	if updatedNames, _ = updated.names(); updatedNames["st"] != "man-st-webapp--004-euw-dev" || updatedNames["rg"] != names["rg"] {
+		t.Errorf("expected the new name of the changed entry, got %v", updatedNames)
+	}
+}
+
+func TestNameSetModifyPlanUnknownResources(t *testing.T) {
+	resetPlannedNames(t)
+
+	_, client := newFakeNamingTool(t)
+	r := &nameSet{client: client}
+
+	config := nameSetModel{
+		Organization: types.StringValue("man"),
+		Application:  types.StringValue("webapp"),
+		Function:     types.StringNull(),
+		Instance:     types.StringNull(),
+		Location:     types.StringValue("euw"),
+		Environment:  types.StringValue("dev"),
+		Resources:    types.MapUnknown(types.ObjectType{AttrTypes: nameSetResourceTypes}),
+		Names:        types.MapUnknown(types.StringType),
+		IDs:          types.MapUnknown(types.Int64Type),
+	}
+
+	// Unknown keys leave the names unknown.
+	plan, diags := modifyPlan(t, r, nil, &config, &config)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !plan.Names.IsUnknown() {
+		t.Errorf("expected unknown names, got %s", plan.Names)
+	}
+
+	// An unknown entry leaves only its name unknown.
+	config.Resources = types.MapValueMust(types.ObjectType{AttrTypes: nameSetResourceTypes}, map[string]attr.Value{
+		"rg": types.ObjectValueMust(nameSetResourceTypes, map[string]attr.Value{
+			"resource_type": types.StringValue("rg"),
+			"organization":  types.StringNull(),
+			"application":   types.StringNull(),
+			"function":      types.StringNull(),
+			"instance":      types.StringNull(),
+			"location":      types.StringNull(),
+			"environment":   types.StringNull(),
+		}),
+		"st": types.ObjectUnknown(nameSetResourceTypes),
+	})
+	plan, diags = modifyPlan(t, r, nil, &config, &config)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if plan.Names.Elements()["rg"].IsUnknown() || !plan.Names.Elements()["st"].IsUnknown() {
+		t.Errorf("expected only the unknown entry to have an unknown name, got %s", plan.Names)
+	}
+}
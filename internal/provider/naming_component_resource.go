@@ -0,0 +1,539 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &namingComponent{}
+	_ resource.ResourceWithConfigure   = &namingComponent{}
+	_ resource.ResourceWithImportState = &namingComponent{}
+	_ resource.ResourceWithModifyPlan  = &namingComponent{}
+)
+
+// namingComponentKind describes a catalogue of name component values maintained in the
+// Azure Naming Tool configuration, such as its locations or environments.
+type namingComponentKind struct {
+	// typeName is the resource type name without the provider prefix.
+	typeName string
+	// title is the singular name of a catalogue entry used in descriptions and diagnostics.
+	title string
+	// path is the API path of the catalogue.
+	path string
+	// example is an example short name used in descriptions.
+	example string
+	// maxLength is the maximum length of short names accepted by the tool, or 0 when unlimited.
+	maxLength int
+}
+
+var (
+	locationComponent = namingComponentKind{
+		typeName: "location",
+		title:    "location",
+		path:     "/api/ResourceLocations",
+		example:  "euw",
+	}
+	environmentComponent = namingComponentKind{
+		typeName: "environment",
+		title:    "environment",
+		path:     "/api/ResourceEnvironments",
+		example:  "prd",
+	}
+	organizationComponent = namingComponentKind{
+		typeName:  "organization",
+		title:     "organization",
+		path:      "/api/ResourceOrgs",
+		example:   "man",
+		maxLength: 5,
+	}
+	unitDepartmentComponent = namingComponentKind{
+		typeName:  "unit_department",
+		title:     "unit or department",
+		path:      "/api/ResourceUnitDepts",
+		example:   "it",
+		maxLength: 3,
+	}
+	projectComponent = namingComponentKind{
+		typeName:  "project",
+		title:     "project, application or service",
+		path:      "/api/ResourceProjAppSvcs",
+		example:   "web",
+		maxLength: 3,
+	}
+	functionComponent = namingComponentKind{
+		typeName:  "function",
+		title:     "function",
+		path:      "/api/ResourceFunctions",
+		example:   "app",
+		maxLength: 10,
+	}
+)
+
+// namingComponentKinds lists all catalogues managed by the provider.
+var namingComponentKinds = []namingComponentKind{
+	locationComponent,
+	environmentComponent,
+	organizationComponent,
+	unitDepartmentComponent,
+	projectComponent,
+	functionComponent,
+}
+
+// NewLocation is a helper function to simplify the provider implementation.
+func NewLocation() resource.Resource {
+	return &namingComponent{kind: locationComponent}
+}
+
+// NewEnvironment is a helper function to simplify the provider implementation.
+func NewEnvironment() resource.Resource {
+	return &namingComponent{kind: environmentComponent}
+}
+
+// NewOrganization is a helper function to simplify the provider implementation.
+func NewOrganization() resource.Resource {
+	return &namingComponent{kind: organizationComponent}
+}
+
+// NewUnitDepartment is a helper function to simplify the provider implementation.
+func NewUnitDepartment() resource.Resource {
+	return &namingComponent{kind: unitDepartmentComponent}
+}
+
+// NewProject is a helper function to simplify the provider implementation.
+func NewProject() resource.Resource {
+	return &namingComponent{kind: projectComponent}
+}
+
+// NewFunction is a helper function to simplify the provider implementation.
+func NewFunction() resource.Resource {
+	return &namingComponent{kind: functionComponent}
+}
+
+// namingComponent is the resource implementation shared by all name component catalogues.
+type namingComponent struct {
+	kind   namingComponentKind
+	client *azurenamingtool.Client
+}
+
+// namingComponentModel maps the resource schema data.
+type namingComponentModel struct {
+	ID        types.Int64  `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	ShortName types.String `tfsdk:"short_name"`
+	SortOrder types.Int64  `tfsdk:"sort_order"`
+}
+
+// namingComponentItem is a catalogue entry as exchanged with the Azure Naming Tool.
+type namingComponentItem struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	ShortName string `json:"shortName"`
+	SortOrder int64  `json:"sortOrder"`
+	Enabled   bool   `json:"enabled"`
+}
+
+// Metadata returns the resource type name.
+func (r *namingComponent) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.kind.typeName
+}
+
+// Schema defines the schema for the resource.
+func (r *namingComponent) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Manages %s of the Azure Naming Tool configuration.", withArticle(r.kind.title)),
+		MarkdownDescription: fmt.Sprintf("Manages %s of the Azure Naming Tool configuration.\n\n", withArticle(r.kind.title)) +
+			"Changes to the catalogue require the `admin_password` provider setting. " +
+			"Names generated before a change keep the short name they were generated with.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description:   fmt.Sprintf("The unique identifier of the %s in the Azure Naming Tool.", r.kind.title),
+				Computed:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Description: fmt.Sprintf("Display name of the %s.", r.kind.title),
+				Required:    true,
+				Validators:  []validator.String{StringNotEmpty()},
+			},
+			"short_name": schema.StringAttribute{
+				Description:         r.shortNameDescription("'"),
+				MarkdownDescription: r.shortNameDescription("`"),
+				Required:            true,
+				Validators:          r.shortNameValidators(),
+			},
+			"sort_order": schema.Int64Attribute{
+				Description:   fmt.Sprintf("Position of the %s in the Azure Naming Tool. Defaults to the end of the list.", r.kind.title),
+				Optional:      true,
+				Computed:      true,
+				Validators:    []validator.Int64{Int64AtLeast(0)},
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+// shortNameDescription describes the short_name attribute, quoting the example with quote.
+func (r *namingComponent) shortNameDescription(quote string) string {
+	description := fmt.Sprintf("Short name of the %s used in generated names (e.g., %s%s%s). Must be unique within the catalogue, ignoring case.",
+		r.kind.title, quote, r.kind.example, quote)
+	if r.kind.maxLength > 0 {
+		description += fmt.Sprintf(" At most %d characters.", r.kind.maxLength)
+	}
+	return description
+}
+
+// shortNameValidators returns the validators of the short_name attribute.
+func (r *namingComponent) shortNameValidators() []validator.String {
+	if r.kind.maxLength > 0 {
+		return []validator.String{StringNotEmpty(), StringLength(1, r.kind.maxLength)}
+	}
+	return []validator.String{StringNotEmpty()}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *namingComponent) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan namingComponentModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	item, err := createNamingComponent(r.client, r.kind, plan.item())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Create %s", titleCase(r.kind.title)),
+			fmt.Sprintf("An error occurred while creating the %s %q: %s", r.kind.title, plan.ShortName.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	plan.setItem(item)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *namingComponent) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state namingComponentModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := getNamingComponent(readOnlyClient(r.client), r.kind, state.ID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Read %s", titleCase(r.kind.title)),
+			fmt.Sprintf("An error occurred while reading %s %d: %s", r.kind.title, state.ID.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	// Entries removed outside of Terraform are recreated on the next apply.
+	if item == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.setItem(*item)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *namingComponent) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state namingComponentModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	plan.ID = state.ID
+	if plan.SortOrder.IsUnknown() {
+		plan.SortOrder = state.SortOrder
+	}
+
+	if err := saveNamingComponent(r.client, r.kind, plan.item()); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Update %s", titleCase(r.kind.title)),
+			fmt.Sprintf("An error occurred while updating %s %d: %s", r.kind.title, plan.ID.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *namingComponent) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state namingComponentModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	err := doNamingToolRequest(r.client, http.MethodDelete, fmt.Sprintf("%s/%d", r.kind.path, state.ID.ValueInt64()), nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Delete %s", titleCase(r.kind.title)),
+			fmt.Sprintf("An error occurred while deleting %s %d: %s", r.kind.title, state.ID.ValueInt64(), err.Error()),
+		)
+	}
+}
+
+// ModifyPlan fails the plan when the planned short name is already used by another resource of
+// the configuration or by another entry of the catalogue.
+func (r *namingComponent) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Skip for destroy operations.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan namingComponentModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || plan.ShortName.IsUnknown() {
+		return
+	}
+
+	shortName := plan.ShortName.ValueString()
+	owner := fmt.Sprintf("proactnaming_%s (name=%q, short_name=%q)", r.kind.typeName, plan.Name.ValueString(), shortName)
+	others, diags := plannedNames.registerPlanned(ctx, req, resp, "catalogue:"+r.kind.path+":"+strings.ToLower(shortName), owner)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(others) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("short_name"),
+			"Duplicate Short Name",
+			fmt.Sprintf("The short name %q is planned by %d other resource(s) in this configuration:\n\n- %s\n\n"+
+				"Short names must be unique within the %s catalogue.", shortName, len(others), strings.Join(others, "\n- "), r.kind.title),
+		)
+		return
+	}
+
+	// Skip if the client is not available (shouldn't happen, but safety check).
+	if r.client == nil {
+		return
+	}
+
+	var state namingComponentModel
+	if !req.State.Raw.IsNull() {
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() || strings.EqualFold(state.ShortName.ValueString(), shortName) {
+			return
+		}
+	}
+
+	items, err := listNamingComponents(readOnlyClient(r.client), r.kind)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Read %s Catalogue", titleCase(r.kind.title)),
+			fmt.Sprintf("An error occurred while checking that the short name %q is unique: %s", shortName, err.Error()),
+		)
+		return
+	}
+	for _, item := range items {
+		if strings.EqualFold(item.ShortName, shortName) && item.ID != state.ID.ValueInt64() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("short_name"),
+				"Duplicate Short Name",
+				fmt.Sprintf("The short name %q is already used by the %s %q with ID %d. "+
+					"Choose another short name or import the existing entry.", shortName, r.kind.title, item.Name, item.ID),
+			)
+			return
+		}
+	}
+}
+
+// ImportState imports an entry by its ID in the Azure Naming Tool.
+func (r *namingComponent) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importInt64ID(ctx, req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *namingComponent) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*azurenamingtool.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *azurenamingtool.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// item returns the catalogue entry described by the model. Unknown sort orders are sent as -1.
+func (m namingComponentModel) item() namingComponentItem {
+	sortOrder := int64(-1)
+	if !m.SortOrder.IsNull() && !m.SortOrder.IsUnknown() {
+		sortOrder = m.SortOrder.ValueInt64()
+	}
+	return namingComponentItem{
+		ID:        m.ID.ValueInt64(),
+		Name:      m.Name.ValueString(),
+		ShortName: m.ShortName.ValueString(),
+		SortOrder: sortOrder,
+		Enabled:   true,
+	}
+}
+
+// setItem updates the model from a catalogue entry.
+func (m *namingComponentModel) setItem(item namingComponentItem) {
+	m.ID = types.Int64Value(item.ID)
+	m.Name = types.StringValue(item.Name)
+	m.ShortName = types.StringValue(item.ShortName)
+	m.SortOrder = types.Int64Value(item.SortOrder)
+}
+
+// listNamingComponents returns all entries of a catalogue.
+func listNamingComponents(client *azurenamingtool.Client, kind namingComponentKind) ([]namingComponentItem, error) {
+	var items []namingComponentItem
+	if err := doNamingToolRequest(client, http.MethodGet, kind.path, nil, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// getNamingComponent returns the entry with the given ID, or nil when it does not exist.
+func getNamingComponent(client *azurenamingtool.Client, kind namingComponentKind, id int64) (*namingComponentItem, error) {
+	items, err := listNamingComponents(client, kind)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		if items[i].ID == id {
+			return &items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// saveNamingComponent creates or, when the ID of the item exists, updates a catalogue entry.
+func saveNamingComponent(client *azurenamingtool.Client, kind namingComponentKind, item namingComponentItem) error {
+	return doNamingToolRequest(client, http.MethodPost, kind.path, item, nil)
+}
+
+// createNamingComponent creates a catalogue entry and returns it with the ID assigned by the tool.
+// Entries without a sort order are appended to the end of the catalogue. The tool does not return
+// the new entry, so it is looked up by its short name afterwards.
+func createNamingComponent(client *azurenamingtool.Client, kind namingComponentKind, item namingComponentItem) (namingComponentItem, error) {
+	items, err := listNamingComponents(client, kind)
+	if err != nil {
+		return item, err
+	}
+
+	for _, existing := range items {
+		if strings.EqualFold(existing.ShortName, item.ShortName) {
+			return item, fmt.Errorf("%s with short name %q already exists with ID %d, import it instead", withArticle(kind.title), existing.ShortName, existing.ID)
+		}
+	}
+
+	if item.SortOrder < 0 {
+		item.SortOrder = 0
+		for _, existing := range items {
+			item.SortOrder = max(item.SortOrder, existing.SortOrder+1)
+		}
+	}
+
+	item.ID = 0
+	if err := saveNamingComponent(client, kind, item); err != nil {
+		return item, err
+	}
+
+	items, err = listNamingComponents(client, kind)
+	if err != nil {
+		return item, err
+	}
+	for _, created := range items {
+		if strings.EqualFold(created.ShortName, item.ShortName) {
+			return created, nil
+		}
+	}
+
+	return item, fmt.Errorf("the %s %q was not found after creating it", kind.title, item.ShortName)
+}
+
+// requireAdminPassword adds an error diagnostic and returns false when the client has no admin password.
+func requireAdminPassword(diags *diag.Diagnostics, client *azurenamingtool.Client) bool {
+	if client != nil && client.AdminPassword != nil && *client.AdminPassword != "" {
+		return true
+	}
+
+	diags.AddError(
+		"Missing Admin Password",
+		"Changing the Azure Naming Tool configuration requires an admin password. "+
+			"Set admin_password in the provider configuration or use the PROACTNAMING_ADMIN_PASSWORD environment variable.",
+	)
+	return false
+}
+
+// importInt64ID imports a resource whose id attribute is the numeric ID of an Azure Naming Tool entry.
+func importInt64ID(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The import ID must be the numeric ID of the entry in the Azure Naming Tool, got %q.", req.ID),
+		)
+		return
+	}
+
+	diags := resp.State.SetAttribute(ctx, path.Root("id"), id)
+	resp.Diagnostics.Append(diags...)
+}
+
+// withArticle prefixes a noun with its indefinite article.
+func withArticle(noun string) string {
+	if noun != "" && strings.ContainsRune("aeio", rune(noun[0])) {
+		return "an " + noun
+	}
+	return "a " + noun
+}
+
+// titleCase capitalises the first letter of each word.
+func titleCase(value string) string {
+	words := strings.Fields(value)
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+func TestNamingComponentLifecycle(t *testing.T) {
+	for _, kind := range namingComponentKinds {
+		t.Run(kind.typeName, func(t *testing.T) {
+			tool, client := newFakeNamingTool(t)
+
+			first, err := createNamingComponent(client, kind, namingComponentItem{Name: "First", ShortName: "one", SortOrder: 5})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if first.ID == 0 || first.SortOrder != 5 {
+				t.Fatalf("expected the created entry to have an ID and sort order 5, got %+v", first)
+			}
+
+			// Entries without a sort order are appended.
+			second, err := createNamingComponent(client, kind, namingComponentItem{Name: "Second", ShortName: "two", SortOrder: -1})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if second.SortOrder != 6 {
+				t.Errorf("expected sort order 6, got %d", second.SortOrder)
+			}
+
+			// Short names are unique.
+			if _, err := createNamingComponent(client, kind, namingComponentItem{Name: "Again", ShortName: "ONE"}); err == nil {
+				t.Error("expected an error for a duplicate short name")
+			}
+
+			second.Name = "Renamed"
+			if err := saveNamingComponent(client, kind, second); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			item, err := getNamingComponent(client, kind, second.ID)
+			if err != nil || item == nil || item.Name != "Renamed" {
+				t.Fatalf("expected the entry to be renamed, got %+v: %v", item, err)
+			}
+			if len(tool.catalogueItems(kind.path)) != 2 {
+				t.Errorf("expected updates not to create entries, got %+v", tool.catalogueItems(kind.path))
+			}
+
+			item, err = getNamingComponent(client, kind, second.ID+100)
+			if err != nil || item != nil {
+				t.Errorf("expected a missing entry to return nil, got %+v: %v", item, err)
+			}
+		})
+	}
+}
+
+func TestRequireAdminPassword(t *testing.T) {
+	empty, password := "", "secret"
+
+	for name, test := range map[string]struct {
+		client   *azurenamingtool.Client
+		expected bool
+	}{
+		"nil client": {client: nil},
+		"nil":        {client: &azurenamingtool.Client{}},
+		"empty":      {client: &azurenamingtool.Client{AdminPassword: &empty}},
+		"configured": {client: &azurenamingtool.Client{AdminPassword: &password}, expected: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			if got := requireAdminPassword(&diags, test.client); got != test.expected || diags.HasError() == test.expected {
+				t.Errorf("expected %t, got %t: %v", test.expected, got, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,567 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &namingConvention{}
+	_ resource.ResourceWithConfigure   = &namingConvention{}
+	_ resource.ResourceWithImportState = &namingConvention{}
+	_ resource.ResourceWithModifyPlan  = &namingConvention{}
+)
+
+// resourceDelimitersPath is the API path of the delimiters of the Azure Naming Tool.
+const resourceDelimitersPath = "/api/ResourceDelimiters"
+
+// namingConventionID is the ID of the naming convention, of which the tool has exactly one.
+const namingConventionID = "naming_convention"
+
+// Severities of the existing_names_check setting.
+const (
+	existingNamesCheckWarn  = "warn"
+	existingNamesCheckError = "error"
+)
+
+// NewNamingConvention is a helper function to simplify the provider implementation.
+func NewNamingConvention() resource.Resource {
+	return &namingConvention{}
+}
+
+// namingConvention is the resource implementation.
+type namingConvention struct {
+	client *azurenamingtool.Client
+}
+
+// namingConventionModel maps the resource schema data.
+type namingConventionModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Components         types.List   `tfsdk:"components"`
+	Delimiter          types.String `tfsdk:"delimiter"`
+	ExistingNamesCheck types.String `tfsdk:"existing_names_check"`
+	AffectedNames      types.List   `tfsdk:"affected_names"`
+}
+
+// resourceDelimiterItem is a delimiter as exchanged with the Azure Naming Tool.
+type resourceDelimiterItem struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Delimiter string `json:"delimiter"`
+	Enabled   bool   `json:"enabled"`
+	SortOrder int64  `json:"sortOrder"`
+}
+
+// Metadata returns the resource type name.
+func (r *namingConvention) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_naming_convention"
+}
+
+// Schema defines the schema for the resource.
+func (r *namingConvention) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the order of the name components and the delimiter of the Azure Naming Tool.",
+		MarkdownDescription: "Manages the order of the name components and the delimiter of the Azure Naming Tool.\n\n" +
+			"The tool has a single naming convention, so a configuration can only contain one of these resources. " +
+			"Components not listed in `components` are disabled. Destroying the resource only removes it from the state; " +
+			"the tool keeps the last applied convention. Changes require the `admin_password` provider setting.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:   "The identifier of the naming convention, always 'naming_convention'.",
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"components": schema.ListAttribute{
+				Description: "Names of the enabled name components, in the order they appear in generated names " +
+					"(e.g., ['ResourceType', 'ResourceOrg', 'Application']). Names are compared ignoring case and spaces.",
+				MarkdownDescription: "Names of the enabled name components, in the order they appear in generated names " +
+					"(e.g., `[\"ResourceType\", \"ResourceOrg\", \"Application\"]`). Names are compared ignoring case and spaces.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"delimiter": schema.StringAttribute{
+				Description:         "The delimiter placed between the components of generated names, one of the delimiters of the tool (e.g., '-').",
+				MarkdownDescription: "The delimiter placed between the components of generated names, one of the delimiters of the tool (e.g., `-`).",
+				Required:            true,
+			},
+			"existing_names_check": schema.StringAttribute{
+				Description: "Checks at plan time which names of the generated names log would change under the planned convention " +
+					"and lists them in affected_names. One of 'warn' or 'error', the severity of the diagnostic reported when names are affected. " +
+					"When omitted, existing names are not checked.",
+				MarkdownDescription: "Checks at plan time which names of the generated names log would change under the planned convention " +
+					"and lists them in `affected_names`. One of `warn` or `error`, the severity of the diagnostic reported when names are affected. " +
+					"When omitted, existing names are not checked.",
+				Optional:   true,
+				Validators: []validator.String{StringOneOf(existingNamesCheckWarn, existingNamesCheckError)},
+			},
+			"affected_names": schema.ListAttribute{
+				Description: "Names of the generated names log that the last planned change of the convention affects, " +
+					"each followed by the name it would be generated as. Only set when existing_names_check is set.",
+				MarkdownDescription: "Names of the generated names log that the last planned change of the convention affects, " +
+					"each followed by the name it would be generated as. Only set when `existing_names_check` is set.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Create applies the configured convention and sets the initial Terraform state.
+func (r *namingConvention) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan namingConventionModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	r.apply(ctx, &resp.Diagnostics, &plan)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *namingConvention) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state namingConventionModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	components, delimiter, err := currentNamingConvention(readOnlyClient(r.client))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Naming Convention",
+			fmt.Sprintf("An error occurred while reading the naming convention: %s", err.Error()),
+		)
+		return
+	}
+
+	// The configured spelling of the components is kept while it denotes the same order.
+	var configured []string
+	if !state.Components.IsNull() {
+		diags = state.Components.ElementsAs(ctx, &configured, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !sameComponents(configured, components) {
+		state.Components, diags = types.ListValueFrom(ctx, types.StringType, components)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	state.ID = types.StringValue(namingConventionID)
+	state.Delimiter = types.StringValue(delimiter)
+	if state.AffectedNames.IsNull() || state.AffectedNames.IsUnknown() {
+		state.AffectedNames = types.ListNull(types.StringType)
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update applies the configured convention and sets the updated Terraform state on success.
+func (r *namingConvention) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan namingConventionModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	r.apply(ctx, &resp.Diagnostics, &plan)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from the Terraform state. The tool keeps the last applied convention.
+func (r *namingConvention) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// ModifyPlan validates the planned convention against the components and delimiters of the tool
+// and, when existing_names_check is set, lists the generated names the change affects.
+func (r *namingConvention) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Skip for destroy operations.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	others, diags := plannedNames.registerPlanned(ctx, req, resp, namingConventionID, "proactnaming_naming_convention")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(others) > 0 {
+		resp.Diagnostics.AddError(
+			"Duplicate Naming Convention",
+			"The Azure Naming Tool has a single naming convention, so a configuration can only contain one proactnaming_naming_convention resource.",
+		)
+		return
+	}
+
+	var plan, state namingConventionModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if !req.State.Raw.IsNull() {
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(namingConventionID)
+
+	// Skip if the convention is not known yet or the client is not available.
+	if plan.Components.IsUnknown() || plan.Delimiter.IsUnknown() || r.client == nil {
+		return
+	}
+
+	var configured []string
+	diags = plan.Components.ElementsAs(ctx, &configured, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, component := range configured {
+		if component == "" {
+			// Unknown elements are resolved during apply.
+			return
+		}
+	}
+
+	components, err := listResourceComponents(readOnlyClient(r.client))
+	if err == nil {
+		var delimiters []resourceDelimiterItem
+		delimiters, err = listResourceDelimiters(readOnlyClient(r.client))
+		if err == nil {
+			_, _, err = resolveNamingConvention(components, delimiters, configured, plan.Delimiter.ValueString())
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Naming Convention",
+			fmt.Sprintf("The planned naming convention cannot be applied: %s", err.Error()),
+		)
+		return
+	}
+
+	// Unchanged conventions keep the names affected by their last change.
+	unchanged := !req.State.Raw.IsNull() && plan.Components.Equal(state.Components) && plan.Delimiter.Equal(state.Delimiter)
+	switch {
+	case plan.ExistingNamesCheck.IsNull():
+		plan.AffectedNames = types.ListNull(types.StringType)
+	case unchanged && !state.AffectedNames.IsNull():
+		plan.AffectedNames = state.AffectedNames
+	default:
+		affected, err := r.affectedNames(configured, plan.Delimiter.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Check Existing Names",
+				fmt.Sprintf("An error occurred while checking which generated names the naming convention affects: %s\n\n"+
+					"Remove existing_names_check or verify that the API key can read the generated names log.", err.Error()),
+			)
+			return
+		}
+
+		plan.AffectedNames, diags = types.ListValueFrom(ctx, types.StringType, affected)
+		resp.Diagnostics.Append(diags...)
+
+		if len(affected) > 0 && !unchanged {
+			summary := "Existing Names Affected by Naming Convention"
+			detail := fmt.Sprintf("The planned naming convention changes %d name(s) of the generated names log:\n\n- %s\n\n"+
+				"Existing names are not renamed, but requesting the same components again produces the new name.",
+				len(affected), strings.Join(affected, "\n- "))
+			if plan.ExistingNamesCheck.ValueString() == existingNamesCheckError {
+				resp.Diagnostics.AddAttributeError(path.Root("existing_names_check"), summary, detail)
+				return
+			}
+			resp.Diagnostics.AddAttributeWarning(path.Root("existing_names_check"), summary, detail)
+		}
+	}
+
+	diags = resp.Plan.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ImportState imports the naming convention. Any import ID is accepted, since there is only one.
+func (r *namingConvention) ImportState(ctx context.Context, _ resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	diags := resp.State.SetAttribute(ctx, path.Root("id"), namingConventionID)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *namingConvention) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*azurenamingtool.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *azurenamingtool.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// apply updates the components and delimiters of the tool that differ from the planned convention.
+func (r *namingConvention) apply(ctx context.Context, diags *diag.Diagnostics, plan *namingConventionModel) {
+	var configured []string
+	diags.Append(plan.Components.ElementsAs(ctx, &configured, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	if err := applyNamingConvention(r.client, configured, plan.Delimiter.ValueString()); err != nil {
+		diags.AddError(
+			"Unable to Update Naming Convention",
+			fmt.Sprintf("An error occurred while updating the naming convention: %s", err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(namingConventionID)
+	if plan.AffectedNames.IsUnknown() {
+		plan.AffectedNames = types.ListNull(types.StringType)
+	}
+}
+
+// affectedNames returns the names of the generated names log that change when the current
+// convention is replaced by the given one, each followed by its new name.
+func (r *namingConvention) affectedNames(components []string, delimiter string) ([]string, error) {
+	currentComponents, currentDelimiter, err := currentNamingConvention(r.client)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := getGeneratedNamesLog(readOnlyClient(r.client))
+	if err != nil {
+		return nil, err
+	}
+	resourceTypes, err := readOnlyClient(r.client).GetResourceTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	// Names of resource types without delimiter only change with the order of the components.
+	undelimited := make(map[string]bool)
+	for _, resourceType := range resourceTypes {
+		if !resourceType.ApplyDelimiter {
+			undelimited[strings.ToLower(resourceType.ShortName)] = true
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	var affected []string
+	for _, entry := range entries {
+		current, planned := currentDelimiter, delimiter
+		if undelimited[strings.ToLower(entry.component("type"))] {
+			current, planned = "", ""
+		}
+
+		before, after := renderName(entry, currentComponents, current), renderName(entry, components, planned)
+		if before != after {
+			affected = append(affected, fmt.Sprintf("%s -> %s", entry.ResourceName, after))
+		}
+	}
+	return affected, nil
+}
+
+// renderName joins the values of the given components of a log entry in order, skipping
+// components without a value.
+func renderName(entry generatedNameLogEntry, components []string, delimiter string) string {
+	values := make([]string, 0, len(components))
+	for _, component := range components {
+		if value := entry.component(component); value != "" {
+			values = append(values, value)
+		}
+	}
+	return strings.Join(values, delimiter)
+}
+
+// sameComponents reports whether two component lists denote the same components in the same order.
+func sameComponents(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if normalizeComponentName(a[i]) != normalizeComponentName(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// listResourceDelimiters returns all delimiters of the tool.
+func listResourceDelimiters(client *azurenamingtool.Client) ([]resourceDelimiterItem, error) {
+	var items []resourceDelimiterItem
+	if err := doNamingToolRequest(client, http.MethodGet, resourceDelimitersPath, nil, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// currentNamingConvention returns the names of the enabled components in order and the active
+// delimiter, which is the enabled delimiter with the lowest sort order.
+func currentNamingConvention(client *azurenamingtool.Client) ([]string, string, error) {
+	components, err := listResourceComponents(client)
+	if err != nil {
+		return nil, "", err
+	}
+	delimiters, err := listResourceDelimiters(client)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sort.SliceStable(components, func(i, j int) bool { return components[i].SortOrder < components[j].SortOrder })
+	names := make([]string, 0, len(components))
+	for _, component := range components {
+		if component.Enabled {
+			names = append(names, component.Name)
+		}
+	}
+
+	var active *resourceDelimiterItem
+	for i := range delimiters {
+		if delimiters[i].Enabled && (active == nil || delimiters[i].SortOrder < active.SortOrder) {
+			active = &delimiters[i]
+		}
+	}
+	if active == nil {
+		return names, "", nil
+	}
+	return names, active.Delimiter, nil
+}
+
+// resolveNamingConvention returns the components and delimiters of the tool updated to the given
+// convention. Listed components are enabled in order and followed by the disabled ones. It fails
+// when a component or the delimiter does not exist or a component is listed twice.
+func resolveNamingConvention(components []customComponentItem, delimiters []resourceDelimiterItem, configured []string, delimiter string) ([]customComponentItem, []resourceDelimiterItem, error) {
+	position := make(map[string]int, len(configured))
+	for i, name := range configured {
+		key := normalizeComponentName(name)
+		if _, ok := position[key]; ok {
+			return nil, nil, fmt.Errorf("the component %q is listed more than once", name)
+		}
+		position[key] = i
+	}
+
+	updated := make([]customComponentItem, len(components))
+	copy(updated, components)
+	sort.SliceStable(updated, func(i, j int) bool { return updated[i].SortOrder < updated[j].SortOrder })
+
+	found := make(map[string]bool, len(configured))
+	disabled := int64(len(configured))
+	for i := range updated {
+		key := normalizeComponentName(updated[i].Name)
+		if _, ok := position[key]; !ok {
+			key = normalizeComponentName(updated[i].DisplayName)
+		}
+		if pos, ok := position[key]; ok && !found[key] {
+			found[key] = true
+			updated[i].Enabled = true
+			updated[i].SortOrder = int64(pos + 1)
+			continue
+		}
+		disabled++
+		updated[i].Enabled = false
+		updated[i].SortOrder = disabled
+	}
+	for _, name := range configured {
+		if !found[normalizeComponentName(name)] {
+			return nil, nil, fmt.Errorf("the Azure Naming Tool has no component %q", name)
+		}
+	}
+
+	updatedDelimiters := make([]resourceDelimiterItem, len(delimiters))
+	copy(updatedDelimiters, delimiters)
+	active := false
+	for i := range updatedDelimiters {
+		updatedDelimiters[i].Enabled = !active && updatedDelimiters[i].Delimiter == delimiter
+		active = active || updatedDelimiters[i].Enabled
+	}
+	if !active {
+		available := make([]string, 0, len(delimiters))
+		for _, item := range delimiters {
+			available = append(available, fmt.Sprintf("%q", item.Delimiter))
+		}
+		return nil, nil, fmt.Errorf("the Azure Naming Tool has no delimiter %q, available delimiters are %s", delimiter, strings.Join(available, ", "))
+	}
+
+	return updated, updatedDelimiters, nil
+}
+
+// applyNamingConvention updates the components and delimiters of the tool that differ from the
+// given convention.
+func applyNamingConvention(client *azurenamingtool.Client, configured []string, delimiter string) error {
+	components, err := listResourceComponents(client)
+	if err != nil {
+		return err
+	}
+	delimiters, err := listResourceDelimiters(client)
+	if err != nil {
+		return err
+	}
+
+	updatedComponents, updatedDelimiters, err := resolveNamingConvention(components, delimiters, configured, delimiter)
+	if err != nil {
+		return err
+	}
+
+	previous := make(map[int64]customComponentItem, len(components))
+	for _, component := range components {
+		previous[component.ID] = component
+	}
+	for _, component := range updatedComponents {
+		if previous[component.ID] == component {
+			continue
+		}
+		if err := doNamingToolRequest(client, http.MethodPost, resourceComponentsPath, component, nil); err != nil {
+			return err
+		}
+	}
+
+	for i, item := range updatedDelimiters {
+		if delimiters[i] == item {
+			continue
+		}
+		if err := doNamingToolRequest(client, http.MethodPost, resourceDelimitersPath, item, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
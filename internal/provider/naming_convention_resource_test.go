@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// seedNamingConvention configures the fake tool with the convention "org-type-application-env"
+// and the delimiters "-" and "_".
+func seedNamingConvention(tool *fakeNamingTool) {
+	tool.components[1] = customComponentItem{ID: 1, Name: "ResourceOrg", DisplayName: "Resource Org", Enabled: true, SortOrder: 1}
+	tool.components[2] = customComponentItem{ID: 2, Name: "ResourceType", DisplayName: "Resource Type", Enabled: true, SortOrder: 2}
+	tool.components[3] = customComponentItem{ID: 3, Name: "application", DisplayName: "Application", Enabled: true, SortOrder: 3, IsCustom: true}
+	tool.components[4] = customComponentItem{ID: 4, Name: "ResourceEnvironment", DisplayName: "Resource Environment", Enabled: true, SortOrder: 4}
+	tool.components[5] = customComponentItem{ID: 5, Name: "ResourceLocation", DisplayName: "Resource Location", SortOrder: 5}
+	tool.delimiters[1] = resourceDelimiterItem{ID: 1, Name: "dash", Delimiter: "-", Enabled: true, SortOrder: 1}
+	tool.delimiters[2] = resourceDelimiterItem{ID: 2, Name: "underscore", Delimiter: "_", SortOrder: 2}
+}
+
+func TestNamingConventionApply(t *testing.T) {
+	tool, client := newFakeNamingTool(t)
+	seedNamingConvention(tool)
+
+	components, delimiter, err := currentNamingConvention(client)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(components, []string{"ResourceOrg", "ResourceType", "application", "ResourceEnvironment"}) || delimiter != "-" {
+		t.Fatalf("unexpected convention %v with delimiter %q", components, delimiter)
+	}
+
+	if err := applyNamingConvention(client, []string{"Resource Type", "organization", "Location", "application"}, "_"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	components, delimiter, err = currentNamingConvention(client)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(components, []string{"ResourceType", "ResourceOrg", "ResourceLocation", "application"}) || delimiter != "_" {
+		t.Errorf("unexpected convention %v with delimiter %q", components, delimiter)
+	}
+	if !sameComponents([]string{"Resource Type", "organization", "Location", "application"}, components) {
+		t.Error("expected the configured spelling to denote the applied components")
+	}
+
+	for name, test := range map[string]struct {
+		components []string
+		delimiter  string
+	}{
+		"unknown component":   {components: []string{"ResourceOrg", "Project"}, delimiter: "-"},
+		"duplicate component": {components: []string{"ResourceOrg", "Resource Org"}, delimiter: "-"},
+		"unknown delimiter":   {components: []string{"ResourceOrg"}, delimiter: "."},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := applyNamingConvention(client, test.components, test.delimiter); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestNamingConventionAffectedNames(t *testing.T) {
+	tool, client := newFakeNamingTool(t)
+	seedNamingConvention(tool)
+	tool.resourceTypes[1] = azurenamingtool.ResourceTypes{ID: 1, ShortName: "rg", ApplyDelimiter: true}
+	tool.resourceTypes[2] = azurenamingtool.ResourceTypes{ID: 2, ShortName: "st", ApplyDelimiter: false}
+
+	for _, resourceType := range []string{"rg", "st"} {
+		if _, err := client.GenerateName(azurenamingtool.GenerateNameRequest{
+			ResourceOrg:         "man",
+			ResourceType:        resourceType,
+			ResourceEnvironment: "dev",
+			CustomComponents:    azurenamingtool.GenerateNameRequestCustomComponents{Application: "web"},
+		}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	r := &namingConvention{client: client}
+
+	// Changing the delimiter only affects names of resource types with a delimiter.
+	affected, err := r.affectedNames([]string{"ResourceOrg", "ResourceType", "application", "ResourceEnvironment"}, "_")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(affected, []string{"man-rg-web-dev -> man_rg_web_dev"}) {
+		t.Errorf("unexpected affected names: %v", affected)
+	}
+
+	// Changing the order affects all names.
+	affected, err = r.affectedNames([]string{"ResourceType", "ResourceOrg", "application", "ResourceEnvironment"}, "-")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(affected) != 2 {
+		t.Errorf("expected both names to be affected, got %v", affected)
+	}
+
+	// The current convention affects no names.
+	affected, err = r.affectedNames([]string{"ResourceOrg", "ResourceType", "application", "ResourceEnvironment"}, "-")
+	if err != nil || len(affected) != 0 {
+		t.Errorf("expected no affected names, got %v: %v", affected, err)
+	}
+}
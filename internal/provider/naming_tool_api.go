@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// generatedNameLogEntry maps an entry of the Azure Naming Tool generated names log.
+type generatedNameLogEntry struct {
+	ID               int64      `json:"id"`
+	CreatedOn        string     `json:"createdOn"`
+	ResourceName     string     `json:"resourceName"`
+	ResourceTypeName string     `json:"resourceTypeName"`
+	Components       [][]string `json:"components"`
+}
+
+// component returns the value of the named component of the log entry.
+// Component names are compared without spaces, case and the "Resource" prefix
+// used by the tool, so "organization" matches both "ResourceOrg" and "Resource Org".
+func (e generatedNameLogEntry) component(name string) string {
+	want := normalizeComponentName(name)
+	for _, pair := range e.Components {
+		if len(pair) < 2 {
+			continue
+		}
+		if normalizeComponentName(pair[0]) == want {
+			return pair[1]
+		}
+	}
+	return ""
+}
+
+// normalizeComponentName maps tool and provider component names to a common form.
+func normalizeComponentName(name string) string {
+	name = strings.ToLower(strings.NewReplacer(" ", "", "_", "").Replace(name))
+	name = strings.TrimPrefix(name, "resource")
+	if name == "organization" {
+		return "org"
+	}
+	return name
+}
+
+// getGeneratedNamesLog returns all entries of the Azure Naming Tool generated names log.
+func getGeneratedNamesLog(client *azurenamingtool.Client) ([]generatedNameLogEntry, error) {
+	var entries []generatedNameLogEntry
+	if err := doNamingToolRequest(client, http.MethodGet, "/api/Admin/GetGeneratedNamesLog", nil, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// doNamingToolRequest performs a request against an Azure Naming Tool endpoint that is not
+// covered by the client library. It authenticates the same way as the client and decodes
+// the JSON response into out when out is not nil.
+func doNamingToolRequest(client *azurenamingtool.Client, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		rb, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(rb)
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(client.HostURL, "/")+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if client.APIKey != "" {
+		req.Header.Set("APIKey", client.APIKey)
+	}
+	if client.AdminPassword != nil {
+		req.Header.Set("AdminPassword", *client.AdminPassword)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "*/*")
+
+	res, err := client.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	rb, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("status: %d, body: %s", res.StatusCode, rb)
+	}
+
+	if out == nil || len(rb) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(rb, out)
+}
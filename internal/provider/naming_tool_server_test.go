@@ -0,0 +1,560 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// fakeNamingTool is an in-memory stand-in for the Azure Naming Tool API used by unit tests.
+type fakeNamingTool struct {
+	mu     sync.Mutex
+	nextID int64
+	names  map[int64]generatedNameLogEntry
+
+	// failInstance makes name requests for this instance fail with a 500 response.
+	failInstance string
+
+	// loseResponses makes name requests fail with a 504 response after registering the name.
+	loseResponses bool
+
+	// failDeletes makes deleting generated names fail with a 500 response.
+	failDeletes bool
+
+	// unreadableLog makes the generated names log fail with a 403 response, like a tool
+	// that only shows it to administrators.
+	unreadableLog bool
+
+	// adminPassword, when set, is required for configuration changes.
+	adminPassword string
+
+	// catalogues holds the configuration catalogues, such as locations, by API path.
+	catalogues map[string]map[int64]namingComponentItem
+
+	// components and componentValues hold the custom components and their allowed values.
+	components      map[int64]customComponentItem
+	componentValues map[int64]customComponentValueItem
+
+	// resourceTypes holds the resource types by ID. Resource types can be updated but not created.
+	resourceTypes map[int64]azurenamingtool.ResourceTypes
+
+	// delimiters holds the delimiters by ID. Delimiters can be updated but not created.
+	delimiters map[int64]resourceDelimiterItem
+
+	// settings holds the global settings.
+	settings namingToolSettings
+
+	// configuration holds the document returned by the configuration export.
+	configuration json.RawMessage
+
+	// apiKeys holds the last regenerated API key by key type.
+	apiKeys map[string]string
+}
+
+// catalogueItems returns the entries of the catalogue with the given API path.
+func (f *fakeNamingTool) catalogueItems(path string) map[int64]namingComponentItem {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	items := make(map[int64]namingComponentItem, len(f.catalogues[path]))
+	for id, item := range f.catalogues[path] {
+		items[id] = item
+	}
+	return items
+}
+
+// newFakeNamingTool starts a fake Azure Naming Tool and returns it with a client pointing at it.
+func newFakeNamingTool(t *testing.T) (*fakeNamingTool, *azurenamingtool.Client) {
+	t.Helper()
+
+	tool := &fakeNamingTool{
+		nextID:          1,
+		names:           make(map[int64]generatedNameLogEntry),
+		catalogues:      make(map[string]map[int64]namingComponentItem),
+		components:      make(map[int64]customComponentItem),
+		componentValues: make(map[int64]customComponentValueItem),
+		resourceTypes:   make(map[int64]azurenamingtool.ResourceTypes),
+		delimiters:      make(map[int64]resourceDelimiterItem),
+		apiKeys:         make(map[string]string),
+	}
+
+	server := httptest.NewServer(tool)
+	t.Cleanup(server.Close)
+
+	host := server.URL
+	apikey := "test"
+	client, err := azurenamingtool.NewClient(&host, &apikey, nil)
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	return tool, client
+}
+
+func (f *fakeNamingTool) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/ResourceNamingRequests/RequestName":
+		var request azurenamingtool.GenerateNameRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if f.failInstance != "" && request.ResourceInstance == f.failInstance {
+			http.Error(w, "instance rejected", http.StatusInternalServerError)
+			return
+		}
+
+		// Like the tool, reject names exceeding the length_max of their resource type without
+		// returning the name or registering it.
+		name := f.render(request)
+		if maxLength := f.lengthMax(request.ResourceType); maxLength > 0 && len(name) > maxLength {
+			writeJSON(w, azurenamingtool.GenerateNameResponse{
+				Message: fmt.Sprintf("Generated name exceeds the maximum length of %d characters.", maxLength),
+			})
+			return
+		}
+
+		entry := generatedNameLogEntry{
+			ID:               f.nextID,
+			CreatedOn:        time.Now().UTC().Format(time.RFC3339Nano),
+			ResourceName:     name,
+			ResourceTypeName: request.ResourceType,
+			Components: [][]string{
+				{"ResourceOrg", request.ResourceOrg},
+				{"ResourceType", request.ResourceType},
+				{"Application", request.CustomComponents.Application},
+				{"ResourceFunction", request.ResourceFunction},
+				{"ResourceInstance", request.ResourceInstance},
+				{"ResourceLocation", request.ResourceLocation},
+				{"ResourceEnvironment", request.ResourceEnvironment},
+			},
+		}
+		f.names[entry.ID] = entry
+		f.nextID++
+
+		if f.loseResponses {
+			http.Error(w, "gateway timeout", http.StatusGatewayTimeout)
+			return
+		}
+
+		writeJSON(w, azurenamingtool.GenerateNameResponse{
+			ResourceName: entry.ResourceName,
+			Success:      true,
+			ResourceNameDetails: azurenamingtool.ResourceNameDetails{
+				ID:               entry.ID,
+				ResourceName:     entry.ResourceName,
+				ResourceTypeName: entry.ResourceTypeName,
+				Components:       entry.Components,
+			},
+		})
+
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/Admin/DeleteGeneratedName/"):
+		id, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/api/Admin/DeleteGeneratedName/"), 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if f.failDeletes {
+			http.Error(w, "delete rejected", http.StatusInternalServerError)
+			return
+		}
+		if _, ok := f.names[id]; !ok {
+			http.Error(w, fmt.Sprintf("name %d not found", id), http.StatusNotFound)
+			return
+		}
+		delete(f.names, id)
+
+	case r.Method == http.MethodGet && r.URL.Path == "/api/Admin/GetGeneratedNamesLog":
+		if f.unreadableLog {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		entries := make([]generatedNameLogEntry, 0, len(f.names))
+		for _, entry := range f.names {
+			entries = append(entries, entry)
+		}
+		writeJSON(w, entries)
+
+	case r.Method == http.MethodGet && f.catalogue(r.URL.Path) != "":
+		items := make([]namingComponentItem, 0)
+		for _, item := range f.catalogues[f.catalogue(r.URL.Path)] {
+			items = append(items, item)
+		}
+		writeJSON(w, items)
+
+	case r.Method == http.MethodPost && f.catalogue(r.URL.Path) != "":
+		if f.adminPassword != "" && r.Header.Get("AdminPassword") != f.adminPassword {
+			http.Error(w, "invalid admin password", http.StatusForbidden)
+			return
+		}
+		var item namingComponentItem
+		if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		path := f.catalogue(r.URL.Path)
+		if f.catalogues[path] == nil {
+			f.catalogues[path] = make(map[int64]namingComponentItem)
+		}
+		if _, ok := f.catalogues[path][item.ID]; !ok {
+			item.ID = f.nextID
+			f.nextID++
+		}
+		f.catalogues[path][item.ID] = item
+		writeJSON(w, "Item added/updated!")
+
+	case r.Method == http.MethodDelete && f.catalogue(path.Dir(r.URL.Path)) != "":
+		if f.adminPassword != "" && r.Header.Get("AdminPassword") != f.adminPassword {
+			http.Error(w, "invalid admin password", http.StatusForbidden)
+			return
+		}
+		id, err := strconv.ParseInt(path.Base(r.URL.Path), 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		catalogue := f.catalogues[f.catalogue(path.Dir(r.URL.Path))]
+		if _, ok := catalogue[id]; !ok {
+			http.Error(w, fmt.Sprintf("item %d not found", id), http.StatusBadRequest)
+			return
+		}
+		delete(catalogue, id)
+
+	case r.Method == http.MethodGet && r.URL.Path == resourceComponentsPath:
+		items := make([]customComponentItem, 0, len(f.components))
+		for _, item := range f.components {
+			items = append(items, item)
+		}
+		writeJSON(w, items)
+
+	case r.Method == http.MethodGet && r.URL.Path == customComponentValuesPath:
+		items := make([]customComponentValueItem, 0, len(f.componentValues))
+		for _, item := range f.componentValues {
+			items = append(items, item)
+		}
+		writeJSON(w, items)
+
+	case r.Method == http.MethodPost && r.URL.Path == resourceComponentsPath:
+		var item customComponentItem
+		if !f.decodeConfiguration(w, r, &item) {
+			return
+		}
+		if _, ok := f.components[item.ID]; !ok {
+			item.ID = f.nextID
+			f.nextID++
+		}
+		f.components[item.ID] = item
+		writeJSON(w, "Item added/updated!")
+
+	case r.Method == http.MethodPost && r.URL.Path == customComponentValuesPath:
+		var item customComponentValueItem
+		if !f.decodeConfiguration(w, r, &item) {
+			return
+		}
+		if _, ok := f.componentValues[item.ID]; !ok {
+			item.ID = f.nextID
+			f.nextID++
+		}
+		f.componentValues[item.ID] = item
+		writeJSON(w, "Item added/updated!")
+
+	case r.Method == http.MethodDelete && path.Dir(r.URL.Path) == resourceComponentsPath:
+		id, _ := strconv.ParseInt(path.Base(r.URL.Path), 10, 64)
+		delete(f.components, id)
+
+	case r.Method == http.MethodDelete && path.Dir(r.URL.Path) == customComponentValuesPath:
+		id, _ := strconv.ParseInt(path.Base(r.URL.Path), 10, 64)
+		delete(f.componentValues, id)
+
+	case r.Method == http.MethodGet && r.URL.Path == resourceTypesPath:
+		items := make([]azurenamingtool.ResourceTypes, 0, len(f.resourceTypes))
+		for _, item := range f.resourceTypes {
+			items = append(items, item)
+		}
+		writeJSON(w, items)
+
+	case r.Method == http.MethodPost && r.URL.Path == resourceTypesPath:
+		var item azurenamingtool.ResourceTypes
+		if !f.decodeConfiguration(w, r, &item) {
+			return
+		}
+		if _, ok := f.resourceTypes[int64(item.ID)]; !ok {
+			http.Error(w, fmt.Sprintf("resource type %d not found", item.ID), http.StatusBadRequest)
+			return
+		}
+		f.resourceTypes[int64(item.ID)] = item
+		writeJSON(w, "Item updated!")
+
+	case r.Method == http.MethodGet && r.URL.Path == resourceDelimitersPath:
+		items := make([]resourceDelimiterItem, 0, len(f.delimiters))
+		for _, item := range f.delimiters {
+			items = append(items, item)
+		}
+		writeJSON(w, items)
+
+	case r.Method == http.MethodPost && r.URL.Path == resourceDelimitersPath:
+		var item resourceDelimiterItem
+		if !f.decodeConfiguration(w, r, &item) {
+			return
+		}
+		if _, ok := f.delimiters[item.ID]; !ok {
+			http.Error(w, fmt.Sprintf("delimiter %d not found", item.ID), http.StatusBadRequest)
+			return
+		}
+		f.delimiters[item.ID] = item
+		writeJSON(w, "Item updated!")
+
+	case r.Method == http.MethodGet && r.URL.Path == settingsPath:
+		if f.adminPassword != "" && r.Header.Get("AdminPassword") != f.adminPassword {
+			http.Error(w, "invalid admin password", http.StatusForbidden)
+			return
+		}
+		writeJSON(w, f.settings)
+
+	case r.Method == http.MethodPost && r.URL.Path == settingsPath:
+		if !f.decodeConfiguration(w, r, &f.settings) {
+			return
+		}
+		writeJSON(w, "Settings updated!")
+
+	case r.Method == http.MethodGet && r.URL.Path == exportConfigurationPath:
+		if f.configuration == nil {
+			writeJSON(w, map[string]any{})
+			return
+		}
+		writeJSON(w, f.configuration)
+
+	case r.Method == http.MethodPost && r.URL.Path == importConfigurationPath:
+		if !f.decodeConfiguration(w, r, &f.configuration) {
+			return
+		}
+		writeJSON(w, "Configuration imported!")
+
+	case r.Method == http.MethodPost && r.URL.Path == generateAPIKeyPath:
+		if f.adminPassword != "" && r.Header.Get("AdminPassword") != f.adminPassword {
+			http.Error(w, "invalid admin password", http.StatusForbidden)
+			return
+		}
+		keyType := r.URL.Query().Get("type")
+		if keyType == "" {
+			http.Error(w, "missing key type", http.StatusBadRequest)
+			return
+		}
+		f.apiKeys[keyType] = fmt.Sprintf("%s-%d", keyType, f.nextID)
+		f.nextID++
+		writeJSON(w, f.apiKeys[keyType])
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// render returns the name of request. Once components are defined, the enabled components are
+// joined in order with the active delimiter, otherwise all components are joined with dashes.
+func (f *fakeNamingTool) render(request azurenamingtool.GenerateNameRequest) string {
+	values := map[string]string{
+		"resourceorg":         request.ResourceOrg,
+		"resourcetype":        request.ResourceType,
+		"application":         request.CustomComponents.Application,
+		"resourcefunction":    request.ResourceFunction,
+		"resourceinstance":    request.ResourceInstance,
+		"resourcelocation":    request.ResourceLocation,
+		"resourceenvironment": request.ResourceEnvironment,
+	}
+
+	var components []customComponentItem
+	for _, component := range f.components {
+		if component.Enabled {
+			components = append(components, component)
+		}
+	}
+	if len(components) == 0 {
+		return strings.Join([]string{
+			request.ResourceOrg, request.ResourceType, request.CustomComponents.Application,
+			request.ResourceFunction, request.ResourceInstance, request.ResourceLocation, request.ResourceEnvironment,
+		}, "-")
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i].SortOrder < components[j].SortOrder })
+
+	delimiter := ""
+	active := int64(-1)
+	for _, item := range f.delimiters {
+		if item.Enabled && (active < 0 || item.SortOrder < active) {
+			delimiter, active = item.Delimiter, item.SortOrder
+		}
+	}
+	for _, resourceType := range f.resourceTypes {
+		if strings.EqualFold(resourceType.ShortName, request.ResourceType) && !resourceType.ApplyDelimiter {
+			delimiter = ""
+		}
+	}
+
+	var parts []string
+	for _, component := range components {
+		if value := values[strings.ToLower(component.Name)]; value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, delimiter)
+}
+
+// lengthMax returns the length_max of the resource type with the given short name, or 0.
+func (f *fakeNamingTool) lengthMax(shortName string) int {
+	for _, resourceType := range f.resourceTypes {
+		if strings.EqualFold(resourceType.ShortName, shortName) {
+			maxLength, _ := strconv.Atoi(resourceType.LengthMax)
+			return maxLength
+		}
+	}
+	return 0
+}
+
+// decodeConfiguration checks the admin password of a configuration change and decodes its body
+// into v. It writes an error response and returns false when either fails.
+func (f *fakeNamingTool) decodeConfiguration(w http.ResponseWriter, r *http.Request, v any) bool {
+	if f.adminPassword != "" && r.Header.Get("AdminPassword") != f.adminPassword {
+		http.Error(w, "invalid admin password", http.StatusForbidden)
+		return false
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// catalogue returns the catalogue path served at the given URL path, or an empty string.
+func (f *fakeNamingTool) catalogue(urlPath string) string {
+	for _, kind := range namingComponentKinds {
+		if urlPath == kind.path {
+			return kind.path
+		}
+	}
+	return ""
+}
+
+// count returns the number of names registered in the fake tool.
+func (f *fakeNamingTool) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.names)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// resetPlannedNames replaces the plan registry with an empty one for the duration of the test.
+func resetPlannedNames(t *testing.T) {
+	registry := plannedNames
+	plannedNames = &planRegistry{entries: make(map[string][]planOwner)}
+	t.Cleanup(func() { plannedNames = registry })
+}
+
+// newPlan returns a plan of schema holding model, or a null plan when model is nil.
+func newPlan[M any](t *testing.T, s schema.Schema, model *M) tfsdk.Plan {
+	t.Helper()
+
+	plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}
+	if model != nil {
+		if diags := plan.Set(context.Background(), model); diags.HasError() {
+			t.Fatalf("unable to set plan: %v", diags)
+		}
+	}
+	return plan
+}
+
+// modifyPlan runs the ModifyPlan method of r for a change from state to plan, with config as the
+// configuration. Nil models stand for a missing state or a destroy plan. It returns the modified
+// plan, or nil for destroy plans, and the diagnostics.
+func modifyPlan[M any](t *testing.T, r resource.ResourceWithModifyPlan, state, config, plan *M) (*M, diag.Diagnostics) {
+	t.Helper()
+
+	resp := runModifyPlan(t, r, state, config, plan, nil)
+	return modifiedPlan[M](t, resp)
+}
+
+// replacePlan runs the ModifyPlan method of r twice for a replacement, like Terraform does:
+// first for the change from state, then without state and with the private state planned by
+// the first call. It returns the result of the second call.
+func replacePlan[M any](t *testing.T, r resource.ResourceWithModifyPlan, state, config, plan *M) (*M, diag.Diagnostics) {
+	t.Helper()
+
+	first := runModifyPlan(t, r, state, config, plan, nil)
+	if first.Diagnostics.HasError() {
+		return nil, first.Diagnostics
+	}
+	resp := runModifyPlan(t, r, nil, config, plan, first.Private)
+	return modifiedPlan[M](t, resp)
+}
+
+// runModifyPlan runs the ModifyPlan method of r with the given prior private state, or an empty
+// one when private is nil. Request and response share the private state, as in the framework.
+func runModifyPlan[M any](t *testing.T, r resource.ResourceWithModifyPlan, state, config, plan *M, private any) resource.ModifyPlanResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: s, Raw: newPlan(t, s, config).Raw},
+		State:  tfsdk.State{Schema: s, Raw: newPlan(t, s, state).Raw},
+		Plan:   newPlan(t, s, plan),
+	}
+
+	if private != nil {
+		reflect.ValueOf(&req.Private).Elem().Set(reflect.ValueOf(private))
+	} else {
+		initPrivateState(&req.Private)
+	}
+
+	resp := resource.ModifyPlanResponse{Plan: req.Plan, Private: req.Private}
+	r.ModifyPlan(ctx, req, &resp)
+	return resp
+}
+
+// initPrivateState sets the private state field pointed to by field to empty private state.
+// The private state type is internal to the framework, so it is created through reflection.
+func initPrivateState(field any) {
+	value := reflect.ValueOf(field).Elem()
+	value.Set(reflect.New(value.Type().Elem()))
+}
+
+// modifiedPlan returns the plan of resp, or nil for destroy plans or on error, and the diagnostics.
+func modifiedPlan[M any](t *testing.T, resp resource.ModifyPlanResponse) (*M, diag.Diagnostics) {
+	t.Helper()
+
+	if resp.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return nil, resp.Diagnostics
+	}
+	var modified M
+	if diags := resp.Plan.Get(context.Background(), &modified); diags.HasError() {
+		t.Fatalf("unable to read modified plan: %v", diags)
+	}
+	return &modified, resp.Diagnostics
+}
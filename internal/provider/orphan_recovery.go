@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// pendingNameKey is the private state key recording a name request of a failed Create.
+const pendingNameKey = "pending_name"
+
+// pendingClockSkew is the allowed difference between the clocks of the provider and the Azure
+// Naming Tool when matching the createdOn timestamp of an entry to a pending name request.
+const pendingClockSkew = time.Minute
+
+// pendingName records a name request whose response was lost, e.g. because the request timed
+// out after the tool registered the name. It is kept in the private state of the resource
+// instance that sent it, so that only the entry registered by that request is adopted.
+type pendingName struct {
+	Components  string    `json:"components"`
+	RequestedAt time.Time `json:"requested_at"`
+	FailedAt    time.Time `json:"failed_at"`
+}
+
+// nameRequestError is returned when a name request fails without a response. The tool may
+// have registered the name nonetheless.
+type nameRequestError struct {
+	pending pendingName
+	err     error
+}
+
+func (e *nameRequestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *nameRequestError) Unwrap() error {
+	return e.err
+}
+
+// pendingNameFrom returns the pending name request recorded in private state, if any.
+func pendingNameFrom(ctx context.Context, private privateStateReader) (*pendingName, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, pendingNameKey)
+	if len(value) == 0 || diags.HasError() {
+		return nil, diags
+	}
+
+	var pending pendingName
+	if err := json.Unmarshal(value, &pending); err != nil {
+		diags.AddError("Unable to Read Pending Name Request", err.Error())
+		return nil, diags
+	}
+	return &pending, diags
+}
+
+// createdOnLayouts are the timestamp layouts used by the Azure Naming Tool for createdOn.
+// Timestamps without a zone are interpreted as UTC.
+var createdOnLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.9999999",
+	"2006-01-02T15:04:05",
+}
+
+// orphanFinder finds entries of the generated names log that were registered by the tool
+// but never recorded in Terraform state, because the response to the name request was lost.
+type orphanFinder struct {
+	mu      sync.Mutex
+	claimed map[int64]bool
+}
+
+// orphans is the process-wide orphan finder.
+var orphans = &orphanFinder{
+	claimed: make(map[int64]bool),
+}
+
+// claim records an entry generated or adopted by this process, which must never be adopted by another resource.
+func (f *orphanFinder) claim(id int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.claimed[id] = true
+}
+
+// find returns the earliest entry of the generated names log with exactly the components of the
+// pending request, created while the request was in flight and not claimed by this process.
+// It returns nil when there is no such entry.
+func (f *orphanFinder) find(client *azurenamingtool.Client, pending pendingName) (*generatedNameLogEntry, error) {
+	entries, err := getGeneratedNamesLog(client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to search the generated names log for the pending name: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	from := pending.RequestedAt.Add(-pendingClockSkew)
+	to := pending.FailedAt.Add(pendingClockSkew)
+
+	var found *generatedNameLogEntry
+	var foundAt time.Time
+	for i, entry := range entries {
+		if f.claimed[entry.ID] || logEntryComponentsKey(entry) != pending.Components {
+			continue
+		}
+
+		createdOn, ok := parseCreatedOn(entry.CreatedOn)
+		if !ok || createdOn.Before(from) || createdOn.After(to) || (found != nil && !createdOn.Before(foundAt)) {
+			continue
+		}
+
+		found, foundAt = &entries[i], createdOn
+	}
+
+	if found != nil {
+		f.claimed[found.ID] = true
+	}
+
+	return found, nil
+}
+
+// logEntryComponentsKey returns the componentsKey of a generated names log entry.
+func logEntryComponentsKey(entry generatedNameLogEntry) string {
+	return logEntryInstanceKey(entry) + "|" + strings.ToLower(entry.component("instance"))
+}
+
+// parseCreatedOn parses the createdOn timestamp of a generated names log entry.
+func parseCreatedOn(value string) (time.Time, bool) {
+	for _, layout := range createdOnLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
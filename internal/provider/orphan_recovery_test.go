@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+func TestOrphanFinderFind(t *testing.T) {
+	_, client := newFakeNamingTool(t)
+	finder := &orphanFinder{claimed: make(map[int64]bool)}
+
+	request := azurenamingtool.GenerateNameRequest{
+		ResourceOrg:         "man",
+		ResourceType:        "st",
+		ResourceInstance:    "001",
+		ResourceLocation:    "euw",
+		ResourceEnvironment: "dev",
+		CustomComponents: azurenamingtool.GenerateNameRequestCustomComponents{
+			Application: "webapp",
+		},
+	}
+	pending := pendingName{Components: componentsKey(request), RequestedAt: time.Now(), FailedAt: time.Now()}
+
+	entry, err := finder.find(client, pending)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if entry != nil {
+		t.Fatalf("expected no orphan in an empty log, got %+v", entry)
+	}
+
+	// The entry registered by the pending request is adopted once.
+	orphan, err := client.GenerateName(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	other := request
+	other.ResourceInstance = "002"
+	if _, err := client.GenerateName(other); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	entry, err = finder.find(client, pending)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if entry == nil || entry.ID != orphan.ResourceNameDetails.ID {
+		t.Fatalf("expected the orphan with ID %d to be found, got %+v", orphan.ResourceNameDetails.ID, entry)
+	}
+
+	entry, _ = finder.find(client, pending)
+	if entry != nil {
+		t.Errorf("expected an adopted entry not to be adopted twice, got %+v", entry)
+	}
+
+	// Entries with the same components registered outside the request, e.g. by another
+	// configuration, are left alone.
+	finder = &orphanFinder{claimed: make(map[int64]bool)}
+	later := pending
+	later.RequestedAt = time.Now().Add(2 * pendingClockSkew)
+	later.FailedAt = later.RequestedAt
+	entry, _ = finder.find(client, later)
+	if entry != nil {
+		t.Errorf("expected entries registered before the request not to be adopted, got %+v", entry)
+	}
+}
+
+func TestGenerateNameRecoversPendingName(t *testing.T) {
+	ctx := context.Background()
+	tool, client := newFakeNamingTool(t)
+
+	// The IDs of the fake tool start at 1 in every test, so claims of other tests are dropped.
+	finder := orphans
+	orphans = &orphanFinder{claimed: make(map[int64]bool)}
+	t.Cleanup(func() { orphans = finder })
+	r := &generateName{client: client}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	plan := generateNameModel{
+		Organization:       types.StringValue("man"),
+		ResourceType:       types.StringValue("rg"),
+		Application:        types.StringValue("webapp"),
+		Function:           types.StringValue("app"),
+		Instance:           types.StringValue("001"),
+		Location:           types.StringValue("euw"),
+		Environment:        types.StringValue("dev"),
+		TruncationPriority: types.ListNull(types.StringType),
+		Keepers:            types.MapNull(types.StringType),
+		ID:                 types.Int64Unknown(),
+		ResourceName:       types.StringUnknown(),
+		Success:            types.BoolUnknown(),
+		Message:            types.StringUnknown(),
+		TruncationApplied:  types.StringUnknown(),
+		OriginalName:       types.StringUnknown(),
+	}
+
+	create := func() resource.CreateResponse {
+		t.Helper()
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: newPlan[generateNameModel](t, s, nil).Raw}}
+		initPrivateState(&resp.Private)
+		r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &resp)
+		return resp
+	}
+
+	read := func(state tfsdk.State, private any) (generateNameModel, resource.ReadResponse) {
+		t.Helper()
+		req := resource.ReadRequest{State: state}
+		initPrivateState(&req.Private)
+		if private != nil {
+			reflect.ValueOf(&req.Private).Elem().Set(reflect.ValueOf(private))
+		}
+		resp := resource.ReadResponse{State: req.State, Private: req.Private}
+		r.Read(ctx, req, &resp)
+
+		var refreshed generateNameModel
+		resp.State.Get(ctx, &refreshed)
+		return refreshed, resp
+	}
+
+	// A Create whose response is lost adopts the entry registered by its request, so that the
+	// resource is not tainted.
+	tool.loseResponses = true
+	createResp := create()
+	tool.loseResponses = false
+	if createResp.Diagnostics.HasError() || createResp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", createResp.Diagnostics)
+	}
+	var created generateNameModel
+	createResp.State.Get(ctx, &created)
+	if created.ID.IsNull() || created.ResourceName.ValueString() != "man-rg-webapp-app-001-euw-dev" || tool.count() != 1 {
+		t.Fatalf("expected the registered name to be adopted, got %s with ID %s and %d names", created.ResourceName, created.ID, tool.count())
+	}
+	if pending, _ := pendingNameFrom(ctx, createResp.Private); pending != nil {
+		t.Errorf("expected the pending request to be removed, got %+v", pending)
+	}
+
+	// The adopted name survives the next apply unchanged.
+	resetPlannedNames(t)
+	refreshed, readResp := read(createResp.State, createResp.Private)
+	if readResp.Diagnostics.HasError() || !refreshed.ID.Equal(created.ID) || !refreshed.ResourceName.Equal(created.ResourceName) {
+		t.Fatalf("expected the refresh to keep the adopted name, got %s with ID %s: %v", refreshed.ResourceName, refreshed.ID, readResp.Diagnostics)
+	}
+	config := plan
+	config.ID, config.ResourceName, config.Success, config.Message = types.Int64Null(), types.StringNull(), types.BoolNull(), types.StringNull()
+	config.TruncationApplied, config.OriginalName = types.StringNull(), types.StringNull()
+	planned, diags := modifyPlan(t, r, &refreshed, &config, &refreshed)
+	if diags.HasError() || !planned.ResourceName.Equal(created.ResourceName) || tool.count() != 1 {
+		t.Errorf("expected the next plan to keep the adopted name, got %s and %d names: %v", planned.ResourceName, tool.count(), diags)
+	}
+
+	// With an unreadable generated names log, the Create fails with its request recorded, and a
+	// later refresh adopts the entry.
+	plan.Instance = types.StringValue("002")
+	tool.loseResponses, tool.unreadableLog = true, true
+	createResp = create()
+	tool.loseResponses = false
+	if !createResp.Diagnostics.HasError() || createResp.State.Raw.IsNull() {
+		t.Fatalf("expected an error and a partial state, got %v", createResp.Diagnostics)
+	}
+	if pending, _ := pendingNameFrom(ctx, createResp.Private); pending == nil || pending.FailedAt.IsZero() {
+		t.Fatalf("expected the failed request to be recorded, got %+v", pending)
+	}
+
+	tool.unreadableLog = false
+	refreshed, readResp = read(createResp.State, createResp.Private)
+	if readResp.Diagnostics.HasError() || readResp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", readResp.Diagnostics)
+	}
+	if refreshed.ResourceName.ValueString() != "man-rg-webapp-app-002-euw-dev" || tool.count() != 2 {
+		t.Errorf("expected the registered name to be adopted, got %s and %d names", refreshed.ResourceName, tool.count())
+	}
+	if pending, _ := pendingNameFrom(ctx, readResp.Private); pending != nil {
+		t.Errorf("expected the pending request to be removed, got %+v", pending)
+	}
+
+	// A refresh that cannot read the log either only warns.
+	plan.Instance = types.StringValue("003")
+	tool.loseResponses, tool.unreadableLog = true, true
+	createResp = create()
+	tool.loseResponses = false
+	refreshed, readResp = read(createResp.State, createResp.Private)
+	if readResp.Diagnostics.HasError() || readResp.Diagnostics.WarningsCount() != 1 || !refreshed.ID.IsNull() {
+		t.Errorf("expected a single warning and no adoption, got ID %s: %v", refreshed.ID, readResp.Diagnostics)
+	}
+	tool.unreadableLog = false
+
+	// A request the tool answers with an error leaves no state behind.
+	plan.Instance = types.StringValue("004")
+	tool.failInstance = "004"
+	createResp = create()
+	if !createResp.Diagnostics.HasError() || !createResp.State.Raw.IsNull() {
+		t.Errorf("expected an error without state, got %v", createResp.Diagnostics)
+	}
+}
+
+func TestParseCreatedOn(t *testing.T) {
+	for _, value := range []string{"2025-03-01T10:11:12.1234567", "2025-03-01T10:11:12", "2025-03-01T10:11:12.123Z"} {
+		createdOn, ok := parseCreatedOn(value)
+		if !ok {
+			t.Errorf("expected %q to be parsed", value)
+			continue
+		}
+		if createdOn.Year() != 2025 || createdOn.Hour() != 10 {
+			t.Errorf("unexpected time %s for %q", createdOn, value)
+		}
+	}
+
+	if _, ok := parseCreatedOn("yesterday"); ok {
+		t.Error("expected an invalid timestamp to be rejected")
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// planRegistry records the component sets and previewed names planned by the resources of a
+// single Terraform run. Terraform starts a new provider process for every plan and apply walk,
+// so the registry only ever holds the resources of one configuration.
+type planRegistry struct {
+	mu      sync.Mutex
+	entries map[string][]planOwner
+}
+
+// planOwner identifies a resource instance registering a key. Registrations with the same
+// non-empty id come from the same resource instance, for example from the two plans Terraform
+// requests for a replacement. The description names the resource in diagnostics.
+type planOwner struct {
+	id          string
+	description string
+}
+
+// plannedNames is the process-wide registry of planned names.
+var plannedNames = &planRegistry{
+	entries: make(map[string][]planOwner),
+}
+
+// planOwnerKey is the private state key holding the plan registry identity of a resource instance.
+const planOwnerKey = "plan_owner"
+
+// planOwnerID returns the plan registry identity of the planned resource instance. The identity
+// is kept in private state, which Terraform passes on to the second plan of a replacement, so that
+// a replaced resource does not clash with itself.
+func planOwnerID(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) (string, diag.Diagnostics) {
+	value, diags := req.Private.GetKey(ctx, planOwnerKey)
+
+	var id string
+	if len(value) > 0 && json.Unmarshal(value, &id) == nil && id != "" {
+		return id, diags
+	}
+
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		diags.AddError("Unable to Identify Planned Resource", err.Error())
+		return "", diags
+	}
+	id = hex.EncodeToString(random)
+
+	if resp.Private != nil {
+		value, _ = json.Marshal(id)
+		diags.Append(resp.Private.SetKey(ctx, planOwnerKey, value)...)
+	}
+	return id, diags
+}
+
+// registerPlanned records that the resource instance planned by req plans to use key and returns
+// the descriptions of the other resources that planned the same key before, if any. The instance
+// is identified by planOwnerID, so that the two plans of a replacement do not clash.
+func (r *planRegistry) registerPlanned(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse,
+	key, description string) ([]string, diag.Diagnostics) {
+	id, diags := planOwnerID(ctx, req, resp)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var others []string
+	for _, other := range r.registerOwner(key, planOwner{id: id, description: description}) {
+		others = append(others, other.description)
+	}
+	return others, diags
+}
+
+// registerOwner records that owner plans to use key and returns the other owners that planned
+// the same key before, if any. A repeated registration by the same owner is only recorded once.
+func (r *planRegistry) registerOwner(key string, owner planOwner) []planOwner {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var others []planOwner
+	registered := false
+	for _, other := range r.entries[key] {
+		if owner.id != "" && other.id == owner.id {
+			registered = true
+			continue
+		}
+		others = append(others, other)
+	}
+
+	if !registered {
+		r.entries[key] = append(r.entries[key], owner)
+	}
+	return others
+}
+
+// checkDuplicate registers the component set and, when known, the name of a planned instance
+// and adds a single error diagnostic listing every resource of the configuration that planned the same.
+// Unchanged resources register their issued names as well, so that new resources cannot reuse them.
+func (r *planRegistry) checkDuplicate(diags *diag.Diagnostics, request azurenamingtool.GenerateNameRequest, name string, owner planOwner) {
+	var others []planOwner
+	var what string
+
+	if previous := r.registerOwner("components:"+componentsKey(request), owner); len(previous) > 0 {
+		others, what = previous, "components"
+	}
+	if name != "" {
+		if previous := r.registerOwner("name:"+strings.ToLower(name), owner); len(previous) > 0 && others == nil {
+			others, what = previous, fmt.Sprintf("name %q", name)
+		}
+	}
+
+	if others == nil {
+		return
+	}
+
+	// The other resources have completed their plan already, so every resource involved is listed here.
+	owners := []string{owner.description + " (this resource)"}
+	for _, other := range others {
+		owners = append(owners, other.description)
+	}
+
+	diags.AddError(
+		"Duplicate Generated Name",
+		fmt.Sprintf("The following %d resources in this configuration plan the same %s:\n\n- %s\n\n"+
+			"They would all receive the same Azure resource name. Change the instance or another component of all but one of them. "+
+			"Terraform does not share resource addresses with providers, so the resources are identified by their components.",
+			len(owners), what, strings.Join(owners, "\n- ")),
+	)
+}
+
+// componentsKey identifies the full component set of a request, including the instance.
+func componentsKey(request azurenamingtool.GenerateNameRequest) string {
+	return instanceKey(request) + "|" + strings.ToLower(request.ResourceInstance)
+}
+
+// describeRequest describes a planned name by its resource type and components.
+func describeRequest(resourceType string, request azurenamingtool.GenerateNameRequest) string {
+	return fmt.Sprintf("%s (organization=%q, resource_type=%q, application=%q, function=%q, instance=%q, location=%q, environment=%q)",
+		resourceType, request.ResourceOrg, request.ResourceType, request.CustomComponents.Application,
+		request.ResourceFunction, request.ResourceInstance, request.ResourceLocation, request.ResourceEnvironment)
+}
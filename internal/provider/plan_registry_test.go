@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+func TestPlanRegistryCheckDuplicate(t *testing.T) {
+	registry := &planRegistry{entries: make(map[string][]planOwner)}
+
+	request := azurenamingtool.GenerateNameRequest{
+		ResourceOrg:         "man",
+		ResourceType:        "st",
+		ResourceInstance:    "001",
+		ResourceLocation:    "euw",
+		ResourceEnvironment: "dev",
+		CustomComponents: azurenamingtool.GenerateNameRequestCustomComponents{
+			Application: "webapp",
+		},
+	}
+	owner := planOwner{id: "first", description: describeRequest("proactnaming_generate_name", request)}
+
+	var diags diag.Diagnostics
+	registry.checkDuplicate(&diags, request, "stmanwebappeuwdev001", owner)
+	if diags.HasError() {
+		t.Fatalf("unexpected error for the first resource: %v", diags)
+	}
+
+	// A repeated registration by the same resource, as for the two plans of a replacement, is no duplicate.
+	registry.checkDuplicate(&diags, request, "stmanwebappeuwdev001", owner)
+	if diags.HasError() {
+		t.Fatalf("unexpected error for a repeated registration: %v", diags)
+	}
+
+	// Different components that produce the same name are reported as a name clash.
+	other := request
+	other.ResourceInstance = "1"
+	otherOwner := planOwner{id: "second", description: describeRequest("proactnaming_generate_name", other)}
+	registry.checkDuplicate(&diags, other, "STMANWEBAPPEUWDEV001", otherOwner)
+	if !diags.HasError() {
+		t.Fatal("expected an error for a duplicate name")
+	}
+	detail := diags[0].Detail()
+	if !strings.Contains(detail, "name \"STMANWEBAPPEUWDEV001\"") || !strings.Contains(detail, owner.description) ||
+		!strings.Contains(detail, otherOwner.description+" (this resource)") {
+		t.Errorf("expected the detail to name the clash and every resource involved, got %q", detail)
+	}
+
+	// Identical components are reported even before the name is known.
+	diags = nil
+	registry.checkDuplicate(&diags, request, "", planOwner{id: "third", description: owner.description})
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), "following 2 resources in this configuration plan the same components") {
+		t.Errorf("expected an error for duplicate components, got %v", diags)
+	}
+}
+
+func TestGenerateNamePlanDuplicates(t *testing.T) {
+	_, client := newFakeNamingTool(t)
+	r := &generateName{client: client}
+
+	config := generateNameModel{
+		Organization:       types.StringValue("man"),
+		ResourceType:       types.StringValue("rg"),
+		Application:        types.StringValue("webapp"),
+		Function:           types.StringValue("app"),
+		Instance:           types.StringValue("001"),
+		Location:           types.StringValue("euw"),
+		Environment:        types.StringValue("dev"),
+		TruncationPriority: types.ListNull(types.StringType),
+		Keepers:            types.MapNull(types.StringType),
+	}
+	planned := func(model generateNameModel) generateNameModel {
+		model.ID = types.Int64Unknown()
+		model.ResourceName = types.StringUnknown()
+		model.Success = types.BoolUnknown()
+		model.Message = types.StringUnknown()
+		model.TruncationApplied = types.StringUnknown()
+		model.OriginalName = types.StringUnknown()
+		return model
+	}
+
+	state := config
+	state.ID = types.Int64Value(1)
+	state.ResourceName = types.StringValue("man-rg-webapp-app-001-euw-dev")
+	state.Success = types.BoolValue(true)
+	state.Message = types.StringValue("")
+	state.TruncationApplied = types.StringValue(truncationNone)
+	state.OriginalName = state.ResourceName
+
+	// Terraform plans a replacement twice, the second time without state.
+	resetPlannedNames(t)
+	replaced := config
+	replaced.Environment = types.StringValue("prd")
+	plan := planned(replaced)
+	if _, diags := replacePlan(t, r, &state, &replaced, &plan); diags.HasError() {
+		t.Fatalf("expected a replaced resource not to clash with itself, got %v", diags)
+	}
+
+	// A new resource repeating the components of an unchanged resource is a duplicate.
+	resetPlannedNames(t)
+	if _, diags := modifyPlan(t, r, &state, &config, &state); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	plan = planned(config)
+	if _, diags := modifyPlan(t, r, nil, &config, &plan); !diags.HasError() {
+		t.Error("expected an error for a new resource repeating the name of an unchanged resource")
+	}
+}
@@ -5,14 +5,18 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/proact-global/azurenamingtool-client-go"
 )
@@ -44,6 +48,16 @@ type proactnamingProviderModel struct {
 	Host          types.String `tfsdk:"host"`
 	APIKey        types.String `tfsdk:"apikey"`
 	AdminPassword types.String `tfsdk:"admin_password"`
+
+	ReadOnlyAPIKey   types.String `tfsdk:"read_only_apikey"`
+	GenerationAPIKey types.String `tfsdk:"generation_apikey"`
+
+	APIKeyFile        types.String `tfsdk:"apikey_file"`
+	AdminPasswordFile types.String `tfsdk:"admin_password_file"`
+	CredentialCommand types.List   `tfsdk:"credential_command"`
+
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
+	MissingAdminPassword  types.String `tfsdk:"missing_admin_password"`
 }
 
 // Metadata returns the provider type name.
@@ -72,6 +86,25 @@ func (p *proactnamingProvider) Schema(_ context.Context, _ provider.SchemaReques
 				Optional:  true,
 				Sensitive: true,
 			},
+			"read_only_apikey": schema.StringAttribute{
+				Description: "Read-only API key of the Azure Naming Tool, used for refreshes, plan checks and data sources. " +
+					"Can also be set via the PROACTNAMING_READ_ONLY_APIKEY environment variable.",
+				MarkdownDescription: "Read-only API key of the Azure Naming Tool, used for refreshes, plan checks and data sources. " +
+					"Can also be set via the `PROACTNAMING_READ_ONLY_APIKEY` environment variable.\n\n" +
+					"When unset, these requests use `apikey`.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"generation_apikey": schema.StringAttribute{
+				Description: "Name generation API key of the Azure Naming Tool, used to generate names and plan previews. " +
+					"Can also be set via the PROACTNAMING_GENERATION_APIKEY environment variable.",
+				MarkdownDescription: "Name generation API key of the Azure Naming Tool, used to generate names and plan previews. " +
+					"Can also be set via the `PROACTNAMING_GENERATION_APIKEY` environment variable.\n\n" +
+					"When unset, these requests use `apikey`. Together with `read_only_apikey` this allows a plan to run without " +
+					"the full-access `apikey`, which is then only needed by applies that delete names or change the configuration of the tool.",
+				Optional:  true,
+				Sensitive: true,
+			},
 			"admin_password": schema.StringAttribute{
 				Description: "Admin password for delete operations in the Azure Naming Tool. Can also be set via the PROACTNAMING_ADMIN_PASSWORD environment variable.",
 				MarkdownDescription: "Admin password for delete operations in the Azure Naming Tool. Can also be set via the `PROACTNAMING_ADMIN_PASSWORD` environment variable.\n\n" +
@@ -79,6 +112,50 @@ func (p *proactnamingProvider) Schema(_ context.Context, _ provider.SchemaReques
 				Optional:  true,
 				Sensitive: true,
 			},
+			"apikey_file": schema.StringAttribute{
+				Description: "Path of a file containing the API key. Conflicts with apikey.",
+				MarkdownDescription: "Path of a file containing the API key. Conflicts with `apikey`.\n\n" +
+					"Surrounding whitespace, such as a trailing newline, is ignored. " +
+					"Use it with a secret mounted by a vault agent or an orchestrator, so that the key is not part of the configuration or the environment.",
+				Optional: true,
+			},
+			"admin_password_file": schema.StringAttribute{
+				Description: "Path of a file containing the admin password. Conflicts with admin_password.",
+				MarkdownDescription: "Path of a file containing the admin password. Conflicts with `admin_password`.\n\n" +
+					"Surrounding whitespace, such as a trailing newline, is ignored.",
+				Optional: true,
+			},
+			"credential_command": schema.ListAttribute{
+				Description: "Program and arguments of a command that prints the credentials as a JSON object " +
+					"with the optional fields apikey, admin_password, read_only_apikey and generation_apikey.",
+				MarkdownDescription: "Program and arguments of a command that prints the credentials as a JSON object " +
+					"with the optional fields `apikey`, `admin_password`, `read_only_apikey` and `generation_apikey`.\n\n" +
+					"The command runs without a shell when the provider is configured, and its output is cached for the rest of the run. " +
+					"Credentials it prints take precedence over the environment variables, while the credential attributes and files " +
+					"of the configuration take precedence over the command. Use it to read the credentials from a vault agent or an OS keyring.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of concurrent requests sent to the Azure Naming Tool. Defaults to 4. " +
+					"Can also be set via the PROACTNAMING_MAX_CONCURRENT_REQUESTS environment variable.",
+				MarkdownDescription: "Maximum number of concurrent requests sent to the Azure Naming Tool. Defaults to `4`. " +
+					"Can also be set via the `PROACTNAMING_MAX_CONCURRENT_REQUESTS` environment variable.\n\n" +
+					"Identical in-flight read requests are collapsed into one and identical name generation requests are serialised, " +
+					"so bursts caused by Terraform's parallelism do not overload the tool.",
+				Optional:   true,
+				Validators: []validator.Int64{Int64AtLeast(1)},
+			},
+			"missing_admin_password": schema.StringAttribute{
+				Description: "Severity of the plan diagnostic shown when a plan deletes generated names but no admin password is configured. " +
+					"One of warning or error. Defaults to warning. Can also be set via the PROACTNAMING_MISSING_ADMIN_PASSWORD environment variable.",
+				MarkdownDescription: "Severity of the plan diagnostic shown when a plan destroys or replaces generated names but no admin password is configured. " +
+					"One of `warning` or `error`. Defaults to `warning`. Can also be set via the `PROACTNAMING_MISSING_ADMIN_PASSWORD` environment variable.\n\n" +
+					"Deleting names requires the admin password, so without it the deletion fails during apply, possibly after other resources were already changed. " +
+					"Set this to `error` to stop such plans before apply.",
+				Optional:   true,
+				Validators: []validator.String{StringOneOf(severityWarning, severityError)},
+			},
 		},
 	}
 }
@@ -113,6 +190,77 @@ func (p *proactnamingProvider) Configure(ctx context.Context, req provider.Confi
 		)
 	}
 
+	if config.ReadOnlyAPIKey.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("read_only_apikey"),
+			"Unknown proactnaming Read-Only API Key",
+			"The provider cannot create the proactnaming API client as there is an unknown configuration value for the read-only API key. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROACTNAMING_READ_ONLY_APIKEY environment variable.",
+		)
+	}
+
+	if config.GenerationAPIKey.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("generation_apikey"),
+			"Unknown proactnaming Generation API Key",
+			"The provider cannot create the proactnaming API client as there is an unknown configuration value for the name generation API key. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROACTNAMING_GENERATION_APIKEY environment variable.",
+		)
+	}
+
+	for _, attribute := range []struct {
+		name    string
+		value   attr.Value
+		summary string
+	}{
+		{"apikey_file", config.APIKeyFile, "Unknown proactnaming API Key File"},
+		{"admin_password_file", config.AdminPasswordFile, "Unknown proactnaming Admin Password File"},
+		{"credential_command", config.CredentialCommand, "Unknown proactnaming Credential Command"},
+	} {
+		if attribute.value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute.name),
+				attribute.summary,
+				fmt.Sprintf("The provider cannot create the proactnaming API client as there is an unknown configuration value for %s. "+
+					"Either target apply the source of the value first or set the value statically in the configuration.", attribute.name),
+			)
+		}
+	}
+
+	if !config.APIKey.IsNull() && !config.APIKeyFile.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("apikey_file"),
+			"Conflicting proactnaming API Key",
+			"Only one of apikey and apikey_file can be set.",
+		)
+	}
+
+	if !config.AdminPassword.IsNull() && !config.AdminPasswordFile.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("admin_password_file"),
+			"Conflicting proactnaming Admin Password",
+			"Only one of admin_password and admin_password_file can be set.",
+		)
+	}
+
+	if config.MaxConcurrentRequests.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Unknown proactnaming Max Concurrent Requests",
+			"The provider cannot create the proactnaming API client as there is an unknown configuration value for the maximum number of concurrent requests. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROACTNAMING_MAX_CONCURRENT_REQUESTS environment variable.",
+		)
+	}
+
+	if config.MissingAdminPassword.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("missing_admin_password"),
+			"Unknown proactnaming Missing Admin Password Severity",
+			"The provider cannot create the proactnaming API client as there is an unknown configuration value for the missing admin password severity. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROACTNAMING_MISSING_ADMIN_PASSWORD environment variable.",
+		)
+	}
+
 	if config.AdminPassword.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("admin_password"),
@@ -132,11 +280,75 @@ func (p *proactnamingProvider) Configure(ctx context.Context, req provider.Confi
 	host := os.Getenv("PROACTNAMING_HOST")
 	apikey := os.Getenv("PROACTNAMING_APIKEY")
 	adminpassword := os.Getenv("PROACTNAMING_ADMIN_PASSWORD")
+	readOnlyAPIKey := os.Getenv("PROACTNAMING_READ_ONLY_APIKEY")
+	generationAPIKey := os.Getenv("PROACTNAMING_GENERATION_APIKEY")
 
 	if !config.Host.IsNull() {
 		host = config.Host.ValueString()
 	}
 
+	// Credentials of the credential command override the environment variables, and credential
+	// files and attributes of the configuration override the command.
+	if !config.CredentialCommand.IsNull() {
+		var command []string
+		diags = config.CredentialCommand.ElementsAs(ctx, &command, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		credentials, err := runCredentialCommand(ctx, command)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credential_command"),
+				"Unable to Run proactnaming Credential Command",
+				fmt.Sprintf("The provider cannot read the credentials of the Azure Naming Tool from the credential command: %s", err.Error()),
+			)
+			return
+		}
+
+		for _, credential := range []struct {
+			target *string
+			value  string
+		}{
+			{&apikey, credentials.APIKey},
+			{&adminpassword, credentials.AdminPassword},
+			{&readOnlyAPIKey, credentials.ReadOnlyAPIKey},
+			{&generationAPIKey, credentials.GenerationAPIKey},
+		} {
+			if credential.value != "" {
+				*credential.target = credential.value
+			}
+		}
+	}
+
+	for _, file := range []struct {
+		name   string
+		path   types.String
+		target *string
+	}{
+		{"apikey_file", config.APIKeyFile, &apikey},
+		{"admin_password_file", config.AdminPasswordFile, &adminpassword},
+	} {
+		if file.path.IsNull() {
+			continue
+		}
+		credential, err := readCredentialFile(file.path.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(file.name),
+				"Unable to Read proactnaming Credential File",
+				fmt.Sprintf("The provider cannot read the credential file of %s: %s", file.name, err.Error()),
+			)
+			continue
+		}
+		*file.target = credential
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if !config.APIKey.IsNull() {
 		apikey = config.APIKey.ValueString()
 	}
@@ -145,6 +357,43 @@ func (p *proactnamingProvider) Configure(ctx context.Context, req provider.Confi
 		adminpassword = config.AdminPassword.ValueString()
 	}
 
+	if !config.ReadOnlyAPIKey.IsNull() {
+		readOnlyAPIKey = config.ReadOnlyAPIKey.ValueString()
+	}
+
+	if !config.GenerationAPIKey.IsNull() {
+		generationAPIKey = config.GenerationAPIKey.ValueString()
+	}
+
+	maxConcurrentRequests := int64(defaultMaxConcurrentRequests)
+	if !config.MaxConcurrentRequests.IsNull() {
+		maxConcurrentRequests = config.MaxConcurrentRequests.ValueInt64()
+	} else if v := os.Getenv("PROACTNAMING_MAX_CONCURRENT_REQUESTS"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || parsed < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid ProAct Naming Max Concurrent Requests",
+				fmt.Sprintf("The PROACTNAMING_MAX_CONCURRENT_REQUESTS environment variable must be a positive whole number, got %q.", v),
+			)
+		}
+		maxConcurrentRequests = parsed
+	}
+
+	missingAdminPassword := severityWarning
+	if !config.MissingAdminPassword.IsNull() {
+		missingAdminPassword = config.MissingAdminPassword.ValueString()
+	} else if v := os.Getenv("PROACTNAMING_MISSING_ADMIN_PASSWORD"); v != "" {
+		if v != severityWarning && v != severityError {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("missing_admin_password"),
+				"Invalid ProAct Naming Missing Admin Password Severity",
+				fmt.Sprintf("The PROACTNAMING_MISSING_ADMIN_PASSWORD environment variable must be %q or %q, got %q.", severityWarning, severityError, v),
+			)
+		}
+		missingAdminPassword = v
+	}
+
 	// If any of the expected configurations are missing, return.
 	// errors with provider-specific guidance.
 
@@ -158,12 +407,15 @@ func (p *proactnamingProvider) Configure(ctx context.Context, req provider.Confi
 		)
 	}
 
-	if apikey == "" {
+	// A plan can run with only the read-only and name generation keys, so the full-access key
+	// is only required when neither is set.
+	if apikey == "" && (readOnlyAPIKey == "" || generationAPIKey == "") {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("apikey"),
 			"Missing ProAct Naming API Key",
 			"The provider cannot create the Azure Naming Tool client as there is a missing or empty value for the API key. "+
-				"Set the apikey value in the configuration or use the PROACTNAMING_APIKEY environment variable. "+
+				"Set the apikey or apikey_file value in the configuration, print it from the credential_command or use the PROACTNAMING_APIKEY environment variable, "+
+				"or set both read_only_apikey and generation_apikey to plan without the full-access key. "+
 				"Obtain your API key from your Azure Naming Tool administrator.",
 		)
 	}
@@ -199,6 +451,22 @@ func (p *proactnamingProvider) Configure(ctx context.Context, req provider.Confi
 		return
 	}
 
+	// Route every request through a transport that bounds concurrency and deduplicates
+	// identical requests. The client timeout is applied per request by the transport, so
+	// that time spent waiting for a free slot does not count towards it.
+	baseTransport := client.HTTPClient.Transport
+	if baseTransport == nil {
+		baseTransport = http.DefaultTransport
+	}
+	client.HTTPClient.Transport = newLimitedTransport(baseTransport, int(maxConcurrentRequests), client.HTTPClient.Timeout)
+	client.HTTPClient.Timeout = 0
+
+	setClientSettings(client, clientSettings{
+		missingAdminPassword: missingAdminPassword,
+		readOnly:             withAPIKey(client, readOnlyAPIKey),
+		generation:           withAPIKey(client, generationAPIKey),
+	})
+
 	// Make the proactnaming client available during DataSource and Resource.
 	// type Configure methods.
 	resp.DataSourceData = client
@@ -210,6 +478,7 @@ func (p *proactnamingProvider) DataSources(_ context.Context) []func() datasourc
 	return []func() datasource.DataSource{
 		NewresourceTypesDataSource,
 		NewGeneratedNameDataSource,
+		NewConfigurationExportDataSource,
 	}
 }
 
@@ -217,5 +486,20 @@ func (p *proactnamingProvider) DataSources(_ context.Context) []func() datasourc
 func (p *proactnamingProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewGenerateName,
+		NewNameSequence,
+		NewNameSet,
+		NewLocation,
+		NewEnvironment,
+		NewOrganization,
+		NewUnitDepartment,
+		NewProject,
+		NewFunction,
+		NewCustomComponent,
+		NewCustomComponentValue,
+		NewResourceTypeConfig,
+		NewNamingConvention,
+		NewSettings,
+		NewConfigurationImport,
+		NewAPIKey,
 	}
 }
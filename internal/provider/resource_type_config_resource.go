@@ -0,0 +1,553 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &resourceTypeConfig{}
+	_ resource.ResourceWithConfigure   = &resourceTypeConfig{}
+	_ resource.ResourceWithImportState = &resourceTypeConfig{}
+	_ resource.ResourceWithModifyPlan  = &resourceTypeConfig{}
+)
+
+// resourceTypesPath is the API path of the resource types of the Azure Naming Tool.
+const resourceTypesPath = "/api/ResourceTypes"
+
+// NewResourceTypeConfig is a helper function to simplify the provider implementation.
+func NewResourceTypeConfig() resource.Resource {
+	return &resourceTypeConfig{}
+}
+
+// resourceTypeConfig is the resource implementation.
+type resourceTypeConfig struct {
+	client *azurenamingtool.Client
+}
+
+// resourceTypeConfigModel maps the resource schema data.
+type resourceTypeConfigModel struct {
+	ID           types.Int64  `tfsdk:"id"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	Resource     types.String `tfsdk:"resource"`
+
+	// Managed settings.
+	Enabled        types.Bool   `tfsdk:"enabled"`
+	Optional       types.String `tfsdk:"optional"`
+	Exclude        types.String `tfsdk:"exclude"`
+	ShortName      types.String `tfsdk:"short_name"`
+	ApplyDelimiter types.Bool   `tfsdk:"apply_delimiter"`
+
+	// Settings at adoption, restored on destroy.
+	Defaults types.Object `tfsdk:"defaults"`
+}
+
+// resourceTypeSettingsModel maps the managed settings of a resource type, as kept in defaults.
+type resourceTypeSettingsModel struct {
+	Enabled        types.Bool   `tfsdk:"enabled"`
+	Optional       types.String `tfsdk:"optional"`
+	Exclude        types.String `tfsdk:"exclude"`
+	ShortName      types.String `tfsdk:"short_name"`
+	ApplyDelimiter types.Bool   `tfsdk:"apply_delimiter"`
+}
+
+// resourceTypeSettingsTypes are the attribute types of resourceTypeSettingsModel.
+var resourceTypeSettingsTypes = map[string]attr.Type{
+	"enabled":         types.BoolType,
+	"optional":        types.StringType,
+	"exclude":         types.StringType,
+	"short_name":      types.StringType,
+	"apply_delimiter": types.BoolType,
+}
+
+// Metadata returns the resource type name.
+func (r *resourceTypeConfig) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resource_type_config"
+}
+
+// Schema defines the schema for the resource.
+func (r *resourceTypeConfig) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the settings of an existing resource type of the Azure Naming Tool.",
+		MarkdownDescription: "Manages the settings of an existing resource type of the Azure Naming Tool.\n\n" +
+			"The resource adopts the resource type with the given short name and manages the settings set in the configuration; " +
+			"settings left unset keep the value of the tool. The settings at adoption are recorded in `defaults` and restored on destroy. " +
+			"Changes require the `admin_password` provider setting.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description:   "The unique identifier of the resource type in the Azure Naming Tool.",
+				Computed:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"resource_type": schema.StringAttribute{
+				Description: "Short name of the resource type to adopt (e.g., 'st'), as listed by the proactnaming_resource_types data source. " +
+					"Changing it forces the adoption of another resource type.",
+				MarkdownDescription: "Short name of the resource type to adopt (e.g., `st`), as listed by the `proactnaming_resource_types` data source. " +
+					"Changing it forces the adoption of another resource type.",
+				Required:      true,
+				Validators:    []validator.String{StringNotEmpty()},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"resource": schema.StringAttribute{
+				Description: "Azure resource type name (e.g., 'Storage/storageAccounts'). " +
+					"Required when several resource types share the short name; otherwise computed.",
+				MarkdownDescription: "Azure resource type name (e.g., `Storage/storageAccounts`). " +
+					"Required when several resource types share the short name; otherwise computed.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description:   "Whether the resource type is enabled in the Azure Naming Tool.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+			"optional": schema.StringAttribute{
+				Description:   "Comma-separated name components that are optional for the resource type.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"exclude": schema.StringAttribute{
+				Description:   "Comma-separated name components that are excluded from names of the resource type.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"short_name": schema.StringAttribute{
+				Description:   "Short name of the resource type used in generated names.",
+				Optional:      true,
+				Computed:      true,
+				Validators:    []validator.String{StringNotEmpty()},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"apply_delimiter": schema.BoolAttribute{
+				Description:   "Whether the delimiter is applied between the components of names of the resource type.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+			"defaults": schema.SingleNestedAttribute{
+				Description:   "The settings of the resource type when it was adopted, restored on destroy.",
+				Computed:      true,
+				PlanModifiers: []planmodifier.Object{objectplanmodifier.UseStateForUnknown()},
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						Description: "Whether the resource type was enabled.",
+						Computed:    true,
+					},
+					"optional": schema.StringAttribute{
+						Description: "The optional name components.",
+						Computed:    true,
+					},
+					"exclude": schema.StringAttribute{
+						Description: "The excluded name components.",
+						Computed:    true,
+					},
+					"short_name": schema.StringAttribute{
+						Description: "The short name.",
+						Computed:    true,
+					},
+					"apply_delimiter": schema.BoolAttribute{
+						Description: "Whether the delimiter was applied.",
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Create adopts the resource type, records its settings as defaults and applies the configured settings.
+func (r *resourceTypeConfig) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan resourceTypeConfigModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	resourceTypes, err := r.client.GetResourceTypes()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource Types",
+			fmt.Sprintf("An error occurred while looking up the resource type %q: %s", plan.ResourceType.ValueString(), err.Error()),
+		)
+		return
+	}
+	resourceType, err := findResourceType(resourceTypes, plan.ResourceType.ValueString(), plan.Resource.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("resource_type"), "Unable to Adopt Resource Type", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(plan.setDefaults(ctx, *resourceType)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated := plan.apply(*resourceType)
+	if err := doNamingToolRequest(r.client, http.MethodPost, resourceTypesPath, updated, nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resource Type",
+			fmt.Sprintf("An error occurred while updating resource type %d: %s", updated.ID, err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.Int64Value(int64(updated.ID))
+	plan.setResourceType(updated)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *resourceTypeConfig) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state resourceTypeConfigModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resourceType, err := getResourceType(readOnlyClient(r.client), state.ID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource Type",
+			fmt.Sprintf("An error occurred while reading resource type %d: %s", state.ID.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	// Resource types removed outside of Terraform can only be adopted again once restored.
+	if resourceType == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Imported states adopt the resource type with its current settings.
+	if state.ResourceType.IsNull() {
+		state.ResourceType = types.StringValue(resourceType.ShortName)
+	}
+	if state.Defaults.IsNull() || state.Defaults.IsUnknown() {
+		resp.Diagnostics.Append(state.setDefaults(ctx, *resourceType)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	state.setResourceType(*resourceType)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update applies the configured settings to the resource type.
+func (r *resourceTypeConfig) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state resourceTypeConfigModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	resourceType, err := getResourceType(r.client, state.ID.ValueInt64())
+	if err == nil && resourceType == nil {
+		err = fmt.Errorf("the resource type no longer exists")
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource Type",
+			fmt.Sprintf("An error occurred while reading resource type %d: %s", state.ID.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	updated := plan.apply(*resourceType)
+	if err := doNamingToolRequest(r.client, http.MethodPost, resourceTypesPath, updated, nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resource Type",
+			fmt.Sprintf("An error occurred while updating resource type %d: %s", updated.ID, err.Error()),
+		)
+		return
+	}
+
+	plan.ID = state.ID
+	plan.Defaults = state.Defaults
+	plan.setResourceType(updated)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete restores the settings the resource type had when it was adopted. The resource type itself is kept.
+func (r *resourceTypeConfig) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state resourceTypeConfigModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || state.Defaults.IsNull() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	resourceType, err := getResourceType(r.client, state.ID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource Type",
+			fmt.Sprintf("An error occurred while reading resource type %d: %s", state.ID.ValueInt64(), err.Error()),
+		)
+		return
+	}
+	if resourceType == nil {
+		return
+	}
+
+	var defaults resourceTypeSettingsModel
+	diags = state.Defaults.As(ctx, &defaults, basetypes.ObjectAsOptions{})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restored := defaults.apply(*resourceType)
+	if err := doNamingToolRequest(r.client, http.MethodPost, resourceTypesPath, restored, nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Restore Resource Type",
+			fmt.Sprintf("An error occurred while restoring the settings of resource type %d: %s", restored.ID, err.Error()),
+		)
+	}
+}
+
+// ModifyPlan fails the plan when another resource of the configuration adopts the same resource
+// type, or when a new resource type configuration cannot adopt its resource type.
+func (r *resourceTypeConfig) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Skip for destroy operations.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan resourceTypeConfigModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || plan.ResourceType.IsUnknown() {
+		return
+	}
+
+	var config resourceTypeConfigModel
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A replacement adopts the resource type of the configuration in both of its plans, so that
+	// they register the same resource type.
+	replaced := req.State.Raw.IsNull()
+	if !replaced {
+		var state resourceTypeConfigModel
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		replaced = !config.ResourceType.Equal(state.ResourceType) || (!config.Resource.IsNull() && !config.Resource.Equal(state.Resource))
+	}
+
+	id := plan.ID.ValueInt64()
+	if replaced {
+		// Skip if the client is not available (shouldn't happen, but safety check).
+		if r.client == nil {
+			return
+		}
+
+		resourceTypes, err := readOnlyClient(r.client).GetResourceTypes()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Resource Types",
+				fmt.Sprintf("An error occurred while looking up the resource type %q: %s", plan.ResourceType.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		resource := ""
+		if !config.Resource.IsUnknown() {
+			resource = config.Resource.ValueString()
+		}
+		resourceType, err := findResourceType(resourceTypes, plan.ResourceType.ValueString(), resource)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("resource_type"), "Unable to Adopt Resource Type", err.Error())
+			return
+		}
+		id = int64(resourceType.ID)
+	}
+
+	owner := fmt.Sprintf("proactnaming_resource_type_config (resource_type=%q)", plan.ResourceType.ValueString())
+	others, diags := plannedNames.registerPlanned(ctx, req, resp, fmt.Sprintf("resource_type_config:%d", id), owner)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(others) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("resource_type"),
+			"Duplicate Resource Type Configuration",
+			fmt.Sprintf("The resource type %d is adopted by %d other resource(s) in this configuration:\n\n- %s\n\n"+
+				"Each resource type can only be managed by one resource.", id, len(others), strings.Join(others, "\n- ")),
+		)
+	}
+}
+
+// ImportState imports a resource type configuration by the ID of the resource type in the Azure Naming Tool.
+// The settings at import are recorded as the defaults restored on destroy.
+func (r *resourceTypeConfig) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importInt64ID(ctx, req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *resourceTypeConfig) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*azurenamingtool.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *azurenamingtool.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// apply returns the resource type with the known settings of the model applied.
+func (m resourceTypeConfigModel) apply(resourceType azurenamingtool.ResourceTypes) azurenamingtool.ResourceTypes {
+	return resourceTypeSettingsModel{
+		Enabled:        m.Enabled,
+		Optional:       m.Optional,
+		Exclude:        m.Exclude,
+		ShortName:      m.ShortName,
+		ApplyDelimiter: m.ApplyDelimiter,
+	}.apply(resourceType)
+}
+
+// setResourceType updates the resource and managed settings of the model from a resource type.
+func (m *resourceTypeConfigModel) setResourceType(resourceType azurenamingtool.ResourceTypes) {
+	m.Resource = types.StringValue(resourceType.Resource)
+	m.Enabled = types.BoolValue(resourceType.Enabled)
+	m.Optional = types.StringValue(resourceType.Optional)
+	m.Exclude = types.StringValue(resourceType.Exclude)
+	m.ShortName = types.StringValue(resourceType.ShortName)
+	m.ApplyDelimiter = types.BoolValue(resourceType.ApplyDelimiter)
+}
+
+// setDefaults records the current settings of a resource type as the defaults of the model.
+func (m *resourceTypeConfigModel) setDefaults(ctx context.Context, resourceType azurenamingtool.ResourceTypes) diag.Diagnostics {
+	defaults, diags := types.ObjectValueFrom(ctx, resourceTypeSettingsTypes, resourceTypeSettingsModel{
+		Enabled:        types.BoolValue(resourceType.Enabled),
+		Optional:       types.StringValue(resourceType.Optional),
+		Exclude:        types.StringValue(resourceType.Exclude),
+		ShortName:      types.StringValue(resourceType.ShortName),
+		ApplyDelimiter: types.BoolValue(resourceType.ApplyDelimiter),
+	})
+	m.Defaults = defaults
+	return diags
+}
+
+// apply returns the resource type with the known settings applied. Null and unknown settings keep
+// the value of the resource type.
+func (s resourceTypeSettingsModel) apply(resourceType azurenamingtool.ResourceTypes) azurenamingtool.ResourceTypes {
+	if !s.Enabled.IsNull() && !s.Enabled.IsUnknown() {
+		resourceType.Enabled = s.Enabled.ValueBool()
+	}
+	if !s.Optional.IsNull() && !s.Optional.IsUnknown() {
+		resourceType.Optional = s.Optional.ValueString()
+	}
+	if !s.Exclude.IsNull() && !s.Exclude.IsUnknown() {
+		resourceType.Exclude = s.Exclude.ValueString()
+	}
+	if !s.ShortName.IsNull() && !s.ShortName.IsUnknown() {
+		resourceType.ShortName = s.ShortName.ValueString()
+	}
+	if !s.ApplyDelimiter.IsNull() && !s.ApplyDelimiter.IsUnknown() {
+		resourceType.ApplyDelimiter = s.ApplyDelimiter.ValueBool()
+	}
+	return resourceType
+}
+
+// getResourceType returns the resource type with the given ID, or nil when it does not exist.
+func getResourceType(client *azurenamingtool.Client, id int64) (*azurenamingtool.ResourceTypes, error) {
+	resourceTypes, err := client.GetResourceTypes()
+	if err != nil {
+		return nil, err
+	}
+	for i := range resourceTypes {
+		if int64(resourceTypes[i].ID) == id {
+			return &resourceTypes[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// findResourceType returns the resource type with the given short name, ignoring case. When
+// resource is not empty, only resource types with that Azure resource type name are considered.
+// It fails when no or several resource types match.
+func findResourceType(resourceTypes []azurenamingtool.ResourceTypes, shortName, resource string) (*azurenamingtool.ResourceTypes, error) {
+	var matches []*azurenamingtool.ResourceTypes
+	for i := range resourceTypes {
+		if !strings.EqualFold(resourceTypes[i].ShortName, shortName) {
+			continue
+		}
+		if resource != "" && !strings.EqualFold(resourceTypes[i].Resource, resource) {
+			continue
+		}
+		matches = append(matches, &resourceTypes[i])
+	}
+
+	switch len(matches) {
+	case 0:
+		if resource != "" {
+			return nil, fmt.Errorf("the Azure Naming Tool has no resource type %q with short name %q", resource, shortName)
+		}
+		return nil, fmt.Errorf("the Azure Naming Tool has no resource type with short name %q", shortName)
+	case 1:
+		return matches[0], nil
+	}
+
+	resources := make([]string, 0, len(matches))
+	for _, match := range matches {
+		resources = append(resources, match.Resource)
+	}
+	return nil, fmt.Errorf("the short name %q is used by several resource types: %s. Set resource to choose one of them",
+		shortName, strings.Join(resources, ", "))
+}
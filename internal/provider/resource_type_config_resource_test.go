@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+func TestFindResourceType(t *testing.T) {
+	resourceTypes := []azurenamingtool.ResourceTypes{
+		{ID: 1, Resource: "Resources/resourcegroups", ShortName: "rg"},
+		{ID: 2, Resource: "Web/sites", ShortName: "app"},
+		{ID: 3, Resource: "Web/sites/slots", ShortName: "app"},
+	}
+
+	for name, test := range map[string]struct {
+		shortName, resource string
+		expected            int
+	}{
+		"unique":        {shortName: "RG", expected: 1},
+		"disambiguated": {shortName: "app", resource: "web/sites/slots", expected: 3},
+		"ambiguous":     {shortName: "app"},
+		"unknown":       {shortName: "st"},
+		"mismatch":      {shortName: "rg", resource: "Web/sites"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			found, err := findResourceType(resourceTypes, test.shortName, test.resource)
+			if test.expected == 0 {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", found)
+				}
+				return
+			}
+			if err != nil || found.ID != test.expected {
+				t.Errorf("expected resource type %d, got %+v: %v", test.expected, found, err)
+			}
+		})
+	}
+}
+
+func TestResourceTypeConfigAdoptAndRestore(t *testing.T) {
+	ctx := context.Background()
+	tool, client := newFakeNamingTool(t)
+	tool.resourceTypes[7] = azurenamingtool.ResourceTypes{
+		ID: 7, Resource: "Storage/storageAccounts", ShortName: "st", Optional: "UnitDept", Enabled: true,
+	}
+
+	original, err := getResourceType(client, 7)
+	if err != nil || original == nil {
+		t.Fatalf("expected the resource type to be found, got %+v: %v", original, err)
+	}
+
+	// Only the configured settings are changed.
+	model := resourceTypeConfigModel{
+		Enabled:        types.BoolValue(false),
+		Optional:       types.StringUnknown(),
+		Exclude:        types.StringValue("Function"),
+		ShortName:      types.StringNull(),
+		ApplyDelimiter: types.BoolNull(),
+	}
+	if diags := model.setDefaults(ctx, *original); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if err := doNamingToolRequest(client, http.MethodPost, resourceTypesPath, model.apply(*original), nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	updated := tool.resourceTypes[7]
+	if updated.Enabled || updated.Exclude != "Function" || updated.Optional != "UnitDept" || updated.ShortName != "st" {
+		t.Errorf("unexpected updated resource type: %+v", updated)
+	}
+
+	// The defaults restore the settings at adoption.
+	var defaults resourceTypeSettingsModel
+	if diags := model.Defaults.As(ctx, &defaults, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if err := doNamingToolRequest(client, http.MethodPost, resourceTypesPath, defaults.apply(updated), nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if restored := tool.resourceTypes[7]; restored != *original {
+		t.Errorf("expected the resource type to be restored to %+v, got %+v", *original, restored)
+	}
+
+	missing, err := getResourceType(client, 8)
+	if err != nil || missing != nil {
+		t.Errorf("expected a missing resource type to return nil, got %+v: %v", missing, err)
+	}
+}
+
+func TestResourceTypeConfigModifyPlanDuplicates(t *testing.T) {
+	resetPlannedNames(t)
+	tool, client := newFakeNamingTool(t)
+	tool.resourceTypes[7] = azurenamingtool.ResourceTypes{ID: 7, Resource: "Storage/storageAccounts", ShortName: "st", Enabled: true}
+	tool.resourceTypes[8] = azurenamingtool.ResourceTypes{ID: 8, Resource: "KeyVault/vaults", ShortName: "kv", Enabled: true}
+	r := &resourceTypeConfig{client: client}
+
+	state := resourceTypeConfigModel{
+		ID:             types.Int64Value(7),
+		ResourceType:   types.StringValue("st"),
+		Resource:       types.StringValue("Storage/storageAccounts"),
+		Enabled:        types.BoolValue(true),
+		Optional:       types.StringValue(""),
+		Exclude:        types.StringValue(""),
+		ShortName:      types.StringValue("st"),
+		ApplyDelimiter: types.BoolValue(false),
+		Defaults:       types.ObjectNull(resourceTypeSettingsTypes),
+	}
+	config := resourceTypeConfigModel{
+		ID:             types.Int64Null(),
+		ResourceType:   types.StringValue("kv"),
+		Resource:       types.StringNull(),
+		Enabled:        types.BoolNull(),
+		Optional:       types.StringNull(),
+		Exclude:        types.StringNull(),
+		ShortName:      types.StringNull(),
+		ApplyDelimiter: types.BoolNull(),
+		Defaults:       types.ObjectNull(resourceTypeSettingsTypes),
+	}
+	// The first plan of the replacement keeps the computed values of the state.
+	plan := state
+	plan.ResourceType = config.ResourceType
+
+	// Switching to another resource type plans the replacement twice without clashing with itself.
+	if _, diags := replacePlan(t, r, &state, &config, &plan); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// The previous resource type is free for another resource.
+	other := config
+	other.ResourceType = types.StringValue("st")
+	if _, diags := modifyPlan(t, r, nil, &other, &other); diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+
+	// A second resource adopting the new resource type is rejected.
+	if _, diags := modifyPlan(t, r, nil, &config, &config); !diags.HasError() {
+		t.Error("expected an error for a second resource with the same resource type")
+	}
+}
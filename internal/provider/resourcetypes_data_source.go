@@ -160,7 +160,7 @@ func (d *resourceTypesDataSource) Schema(_ context.Context, _ datasource.SchemaR
 func (d *resourceTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state resourceTypesDataSourceModel
 
-	resourceTypes, err := d.client.GetResourceTypes()
+	resourceTypes, err := readOnlyClient(d.client).GetResourceTypes()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read azurenamingtool resource_types",
@@ -0,0 +1,279 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &settings{}
+	_ resource.ResourceWithConfigure   = &settings{}
+	_ resource.ResourceWithImportState = &settings{}
+	_ resource.ResourceWithModifyPlan  = &settings{}
+)
+
+// settingsPath is the API path of the global settings of the Azure Naming Tool.
+const settingsPath = "/api/Admin/Settings"
+
+// settingsID is the ID of the settings, of which the tool has exactly one set.
+const settingsID = "settings"
+
+// NewSettings is a helper function to simplify the provider implementation.
+func NewSettings() resource.Resource {
+	return &settings{}
+}
+
+// settings is the resource implementation.
+type settings struct {
+	client *azurenamingtool.Client
+}
+
+// settingsModel maps the resource schema data.
+type settingsModel struct {
+	ID                             types.String `tfsdk:"id"`
+	AllowDuplicateNames            types.Bool   `tfsdk:"allow_duplicate_names"`
+	GeneratedNamesLog              types.Bool   `tfsdk:"generated_names_log"`
+	GeneratedNamesLogRetentionDays types.Int64  `tfsdk:"generated_names_log_retention_days"`
+	AutoIncrementInstance          types.Bool   `tfsdk:"auto_increment_instance"`
+}
+
+// namingToolSettings are the global settings as exchanged with the Azure Naming Tool.
+type namingToolSettings struct {
+	DuplicateNamesAllowed          bool  `json:"duplicateNamesAllowed"`
+	GeneratedNamesLogEnabled       bool  `json:"generatedNamesLogEnabled"`
+	GeneratedNamesLogRetentionDays int64 `json:"generatedNamesLogRetentionDays"`
+	AutoIncrementResourceInstance  bool  `json:"autoIncrementResourceInstance"`
+}
+
+// Metadata returns the resource type name.
+func (r *settings) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_settings"
+}
+
+// Schema defines the schema for the resource.
+func (r *settings) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the global settings of the Azure Naming Tool.",
+		MarkdownDescription: "Manages the global settings of the Azure Naming Tool.\n\n" +
+			"The tool has a single set of settings, so a configuration can only contain one of these resources. " +
+			"Settings left unset keep the value of the tool. Destroying the resource only removes it from the state; " +
+			"the tool keeps the last applied settings. Reading and changing the settings requires the `admin_password` provider setting.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:   "The identifier of the settings, always 'settings'.",
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"allow_duplicate_names": schema.BoolAttribute{
+				Description:   "Whether the tool generates names that are already in the generated names log. When false, duplicate names are rejected.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+			"generated_names_log": schema.BoolAttribute{
+				Description: "Whether generated names are recorded in the generated names log. " +
+					"The provider relies on the log to allocate instances, import names and recover orphaned entries.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+			"generated_names_log_retention_days": schema.Int64Attribute{
+				Description:   "Number of days entries are kept in the generated names log, or 0 to keep them indefinitely.",
+				Optional:      true,
+				Computed:      true,
+				Validators:    []validator.Int64{Int64AtLeast(0)},
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"auto_increment_instance": schema.BoolAttribute{
+				Description:   "Whether the tool increments the instance of a requested name that is already in the generated names log.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+// Create applies the configured settings and sets the initial Terraform state.
+func (r *settings) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan settingsModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	if err := r.apply(&plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Settings",
+			fmt.Sprintf("An error occurred while updating the settings: %s", err.Error()),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *settings) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state settingsModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	current, err := getNamingToolSettings(readOnlyClient(r.client))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Settings",
+			fmt.Sprintf("An error occurred while reading the settings: %s", err.Error()),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(settingsID)
+	state.setSettings(current)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update applies the configured settings and sets the updated Terraform state on success.
+func (r *settings) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan settingsModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !requireAdminPassword(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	if err := r.apply(&plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Settings",
+			fmt.Sprintf("An error occurred while updating the settings: %s", err.Error()),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from the Terraform state. The tool keeps the last applied settings.
+func (r *settings) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// ModifyPlan fails the plan when the configuration contains more than one settings resource.
+func (r *settings) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Skip for destroy operations.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	others, diags := plannedNames.registerPlanned(ctx, req, resp, settingsID, "proactnaming_settings")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(others) > 0 {
+		resp.Diagnostics.AddError(
+			"Duplicate Settings",
+			"The Azure Naming Tool has a single set of settings, so a configuration can only contain one proactnaming_settings resource.",
+		)
+	}
+}
+
+// ImportState imports the settings. Any import ID is accepted, since there is only one set.
+func (r *settings) ImportState(ctx context.Context, _ resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	diags := resp.State.SetAttribute(ctx, path.Root("id"), settingsID)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *settings) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*azurenamingtool.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *azurenamingtool.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// apply writes the known settings of the model to the tool and updates the model with the result.
+func (r *settings) apply(plan *settingsModel) error {
+	current, err := getNamingToolSettings(r.client)
+	if err != nil {
+		return err
+	}
+
+	updated := plan.settings(current)
+	if updated != current {
+		if err := doNamingToolRequest(r.client, http.MethodPost, settingsPath, updated, nil); err != nil {
+			return err
+		}
+	}
+
+	plan.ID = types.StringValue(settingsID)
+	plan.setSettings(updated)
+	return nil
+}
+
+// settings returns the given settings with the known settings of the model applied.
+func (m settingsModel) settings(current namingToolSettings) namingToolSettings {
+	if !m.AllowDuplicateNames.IsNull() && !m.AllowDuplicateNames.IsUnknown() {
+		current.DuplicateNamesAllowed = m.AllowDuplicateNames.ValueBool()
+	}
+	if !m.GeneratedNamesLog.IsNull() && !m.GeneratedNamesLog.IsUnknown() {
+		current.GeneratedNamesLogEnabled = m.GeneratedNamesLog.ValueBool()
+	}
+	if !m.GeneratedNamesLogRetentionDays.IsNull() && !m.GeneratedNamesLogRetentionDays.IsUnknown() {
+		current.GeneratedNamesLogRetentionDays = m.GeneratedNamesLogRetentionDays.ValueInt64()
+	}
+	if !m.AutoIncrementInstance.IsNull() && !m.AutoIncrementInstance.IsUnknown() {
+		current.AutoIncrementResourceInstance = m.AutoIncrementInstance.ValueBool()
+	}
+	return current
+}
+
+// setSettings updates the model from the settings of the tool.
+func (m *settingsModel) setSettings(current namingToolSettings) {
+	m.AllowDuplicateNames = types.BoolValue(current.DuplicateNamesAllowed)
+	m.GeneratedNamesLog = types.BoolValue(current.GeneratedNamesLogEnabled)
+	m.GeneratedNamesLogRetentionDays = types.Int64Value(current.GeneratedNamesLogRetentionDays)
+	m.AutoIncrementInstance = types.BoolValue(current.AutoIncrementResourceInstance)
+}
+
+// getNamingToolSettings returns the global settings of the tool.
+func getNamingToolSettings(client *azurenamingtool.Client) (namingToolSettings, error) {
+	var current namingToolSettings
+	err := doNamingToolRequest(client, http.MethodGet, settingsPath, nil, &current)
+	return current, err
+}
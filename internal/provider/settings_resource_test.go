@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSettingsApply(t *testing.T) {
+	tool, client := newFakeNamingTool(t)
+	password := "secret"
+	tool.adminPassword = password
+	client.AdminPassword = &password
+	tool.settings = namingToolSettings{GeneratedNamesLogEnabled: true, GeneratedNamesLogRetentionDays: 30}
+
+	r := &settings{client: client}
+
+	// Only the configured settings are changed.
+	plan := settingsModel{
+		AllowDuplicateNames:            types.BoolValue(false),
+		GeneratedNamesLog:              types.BoolUnknown(),
+		GeneratedNamesLogRetentionDays: types.Int64Value(365),
+		AutoIncrementInstance:          types.BoolNull(),
+	}
+	if err := r.apply(&plan); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := namingToolSettings{GeneratedNamesLogEnabled: true, GeneratedNamesLogRetentionDays: 365}
+	current, err := getNamingToolSettings(client)
+	if err != nil || current != expected {
+		t.Errorf("expected settings %+v, got %+v: %v", expected, current, err)
+	}
+	if plan.ID.ValueString() != settingsID || !plan.GeneratedNamesLog.ValueBool() || plan.AutoIncrementInstance.ValueBool() {
+		t.Errorf("expected the plan to be updated with the applied settings, got %+v", plan)
+	}
+
+	// Reading the settings requires the admin password.
+	client.AdminPassword = nil
+	if _, err := getNamingToolSettings(client); err == nil {
+		t.Error("expected an error without the admin password")
+	}
+}
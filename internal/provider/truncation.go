@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+// Truncation strategies supported by the generate_name resource.
+const (
+	truncationError             = "error"
+	truncationShortenComponents = "shorten_components"
+	truncationHash              = "hash"
+
+	// truncationNone is reported when the generated name fits without truncation.
+	truncationNone = "none"
+
+	// truncationHashLength is the number of hexadecimal characters used by the hash strategy.
+	truncationHashLength = 4
+)
+
+// truncationComponents lists the free-text components that may be shortened, in their default priority.
+// Catalogue components such as organization or location are short codes and are never shortened.
+var truncationComponents = []string{"application", "function"}
+
+// nameLengthError is returned when a generated name exceeds the resource type's length_max
+// and the configured truncation strategy cannot bring it within the limit.
+type nameLengthError struct {
+	name         string
+	resourceType string
+	maxLength    int
+}
+
+func (e *nameLengthError) Error() string {
+	return fmt.Sprintf("generated name %q is %d characters long, which exceeds the maximum of %d characters for resource type %q",
+		e.name, len(e.name), e.maxLength, e.resourceType)
+}
+
+// resourceTypeLimits returns the length_max of the resource type with the given short name and
+// whether its names use the delimiter. A length of 0 means the resource type is unknown or has
+// no maximum length.
+func resourceTypeLimits(client *azurenamingtool.Client, shortName string) (int, bool, error) {
+	resourceTypes, err := client.GetResourceTypes()
+	if err != nil {
+		return 0, false, err
+	}
+
+	for _, resourceType := range resourceTypes {
+		if !strings.EqualFold(resourceType.ShortName, shortName) {
+			continue
+		}
+
+		maxLength, err := strconv.Atoi(strings.TrimSpace(resourceType.LengthMax))
+		if err != nil {
+			return 0, resourceType.ApplyDelimiter, nil
+		}
+		return maxLength, resourceType.ApplyDelimiter, nil
+	}
+
+	return 0, false, nil
+}
+
+// requestedName renders the name the tool generates for request from its enabled components
+// and active delimiter. Names of resource types that do not use the delimiter are joined without it.
+func requestedName(client *azurenamingtool.Client, request azurenamingtool.GenerateNameRequest, applyDelimiter bool) (string, error) {
+	components, delimiter, err := currentNamingConvention(client)
+	if err != nil {
+		return "", err
+	}
+	if !applyDelimiter {
+		delimiter = ""
+	}
+	return renderName(requestLogEntry(request), components, delimiter), nil
+}
+
+// requestLogEntry returns a generated names log entry holding the components of request, as
+// the tool records them.
+func requestLogEntry(request azurenamingtool.GenerateNameRequest) generatedNameLogEntry {
+	return generatedNameLogEntry{
+		ResourceTypeName: request.ResourceType,
+		Components: [][]string{
+			{"ResourceOrg", request.ResourceOrg},
+			{"ResourceType", request.ResourceType},
+			{"Application", request.CustomComponents.Application},
+			{"ResourceFunction", request.ResourceFunction},
+			{"ResourceInstance", request.ResourceInstance},
+			{"ResourceLocation", request.ResourceLocation},
+			{"ResourceEnvironment", request.ResourceEnvironment},
+		},
+	}
+}
+
+// componentValue returns a pointer to the request field backing the named free-text component.
+func componentValue(request *azurenamingtool.GenerateNameRequest, component string) *string {
+	switch component {
+	case "application":
+		return &request.CustomComponents.Application
+	case "function":
+		return &request.ResourceFunction
+	}
+	return nil
+}
+
+// shortenComponents trims overflow characters from the components in priority order.
+// Each component keeps at least one character. It returns the shortened request and
+// the number of characters that could not be removed.
+func shortenComponents(request azurenamingtool.GenerateNameRequest, priority []string, overflow int) (azurenamingtool.GenerateNameRequest, int) {
+	for _, component := range priority {
+		if overflow <= 0 {
+			break
+		}
+
+		value := componentValue(&request, component)
+		if value == nil || len(*value) <= 1 {
+			continue
+		}
+
+		trim := min(overflow, len(*value)-1)
+		*value = (*value)[:len(*value)-trim]
+		overflow -= trim
+	}
+
+	return request, overflow
+}
+
+// hashComponents replaces the overflow characters at the end of the first non-empty
+// component in priority order with a short hash of the original name.
+// It returns false when no component is long enough to carry the hash.
+func hashComponents(request azurenamingtool.GenerateNameRequest, priority []string, originalName string, overflow int) (azurenamingtool.GenerateNameRequest, bool) {
+	sum := sha256.Sum256([]byte(originalName))
+	hash := hex.EncodeToString(sum[:])[:truncationHashLength]
+
+	for _, component := range priority {
+		value := componentValue(&request, component)
+		if value == nil || *value == "" {
+			continue
+		}
+
+		keep := len(*value) - overflow - truncationHashLength
+		if keep < 0 {
+			continue
+		}
+
+		*value = (*value)[:keep] + hash
+		return request, true
+	}
+
+	return request, false
+}
@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/proact-global/azurenamingtool-client-go"
+)
+
+func TestShortenComponents(t *testing.T) {
+	request := azurenamingtool.GenerateNameRequest{
+		ResourceFunction: "data",
+		CustomComponents: azurenamingtool.GenerateNameRequestCustomComponents{
+			Application: "webapp",
+		},
+	}
+
+	testCases := map[string]struct {
+		priority            []string
+		overflow            int
+		expectedApplication string
+		expectedFunction    string
+		expectedRemaining   int
+	}{
+		"application only": {
+			priority:            []string{"application", "function"},
+			overflow:            2,
+			expectedApplication: "weba",
+			expectedFunction:    "data",
+		},
+		"spills into function": {
+			priority:            []string{"application", "function"},
+			overflow:            7,
+			expectedApplication: "w",
+			expectedFunction:    "da",
+		},
+		"function first": {
+			priority:            []string{"function"},
+			overflow:            1,
+			expectedApplication: "webapp",
+			expectedFunction:    "dat",
+		},
+		"not enough characters": {
+			priority:            []string{"application", "function"},
+			overflow:            10,
+			expectedApplication: "w",
+			expectedFunction:    "d",
+			expectedRemaining:   2,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, remaining := shortenComponents(request, testCase.priority, testCase.overflow)
+
+			if got.CustomComponents.Application != testCase.expectedApplication {
+				t.Errorf("expected application %q, got %q", testCase.expectedApplication, got.CustomComponents.Application)
+			}
+			if got.ResourceFunction != testCase.expectedFunction {
+				t.Errorf("expected function %q, got %q", testCase.expectedFunction, got.ResourceFunction)
+			}
+			if remaining != testCase.expectedRemaining {
+				t.Errorf("expected %d remaining characters, got %d", testCase.expectedRemaining, remaining)
+			}
+		})
+	}
+
+	if request.CustomComponents.Application != "webapp" {
+		t.Errorf("expected the original request to be left untouched, got application %q", request.CustomComponents.Application)
+	}
+}
+
+func TestHashComponents(t *testing.T) {
+	request := azurenamingtool.GenerateNameRequest{
+		CustomComponents: azurenamingtool.GenerateNameRequestCustomComponents{
+			Application: "customerportal",
+		},
+	}
+
+	got, ok := hashComponents(request, truncationComponents, "stmancustomerportaleuwdev001", 3)
+	if !ok {
+		t.Fatal("expected the hash to fit in the application component")
+	}
+
+	application := got.CustomComponents.Application
+	if len(application) != len("customerportal")-3 {
+		t.Errorf("expected application to shrink by 3 characters, got %q", application)
+	}
+	if application[:7] != "custome" {
+		t.Errorf("expected application to keep its prefix, got %q", application)
+	}
+
+	again, _ := hashComponents(request, truncationComponents, "stmancustomerportaleuwdev001", 3)
+	if again.CustomComponents.Application != application {
+		t.Errorf("expected the hash to be deterministic, got %q and %q", application, again.CustomComponents.Application)
+	}
+
+	if _, ok := hashComponents(request, truncationComponents, "name", 20); ok {
+		t.Error("expected the hash not to fit when the overflow exceeds the component length")
+	}
+}
+
+func TestGenerateNameTruncation(t *testing.T) {
+	tests := map[string]struct {
+		truncation string
+		priority   []string
+		expected   string
+		err        bool
+	}{
+		"error":                     {truncation: truncationError, err: true},
+		"unset":                     {err: true},
+		"shorten_components":        {truncation: truncationShortenComponents, expected: "manstcustodata001dev"},
+		"shorten function first":    {truncation: truncationShortenComponents, priority: []string{"function", "application"}, expected: "manstcustomerd001dev"},
+		"shorten function only":     {truncation: truncationShortenComponents, priority: []string{"function"}, err: true},
+		"hash":                      {truncation: truncationHash},
+		"hash function too short":   {truncation: truncationHash, priority: []string{"function"}, err: true},
+		"hash application priority": {truncation: truncationHash, priority: []string{"application"}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tool, client := newFakeNamingTool(t)
+			for i, component := range []string{"ResourceOrg", "ResourceType", "application", "ResourceFunction", "ResourceInstance", "ResourceEnvironment"} {
+				tool.components[int64(i+1)] = customComponentItem{ID: int64(i + 1), Name: component, Enabled: true, SortOrder: int64(i + 1)}
+			}
+			tool.delimiters[1] = resourceDelimiterItem{ID: 1, Name: "dash", Delimiter: "-", Enabled: true, SortOrder: 1}
+			tool.resourceTypes[1] = azurenamingtool.ResourceTypes{ID: 1, ShortName: "st", LengthMax: "20"}
+
+			model := generateNameModel{
+				Organization:       types.StringValue("man"),
+				ResourceType:       types.StringValue("st"),
+				Application:        types.StringValue("customerportal"),
+				Function:           types.StringValue("data"),
+				Instance:           types.StringValue("001"),
+				Environment:        types.StringValue("dev"),
+				Truncation:         types.StringNull(),
+				TruncationPriority: types.ListNull(types.StringType),
+			}
+			if test.truncation != "" {
+				model.Truncation = types.StringValue(test.truncation)
+			}
+			if test.priority != nil {
+				model.TruncationPriority, _ = types.ListValueFrom(context.Background(), types.StringType, test.priority)
+			}
+
+			r := &generateName{client: client}
+			response, err := r.generate(context.Background(), &model, nil)
+
+			if model.OriginalName.ValueString() != "manstcustomerportaldata001dev" {
+				t.Errorf("expected the full name as original_name, got %s", model.OriginalName)
+			}
+
+			if test.err {
+				var lengthErr *nameLengthError
+				if !errors.As(err, &lengthErr) || lengthErr.name != "manstcustomerportaldata001dev" {
+					t.Fatalf("expected a length error for the full name, got %v", err)
+				}
+				if len(tool.names) != 0 {
+					t.Errorf("expected no registered names, got %v", tool.names)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !response.Success || len(response.ResourceName) != 20 {
+				t.Errorf("expected a successful name of 20 characters, got %+v", response)
+			}
+			if test.expected != "" && response.ResourceName != test.expected {
+				t.Errorf("expected name %q, got %q", test.expected, response.ResourceName)
+			}
+			if test.truncation == truncationHash && !strings.HasPrefix(response.ResourceName, "manstc") {
+				t.Errorf("expected the hash to replace the end of the application, got %q", response.ResourceName)
+			}
+			if model.TruncationApplied.ValueString() != test.truncation {
+				t.Errorf("expected truncation_applied %q, got %s", test.truncation, model.TruncationApplied)
+			}
+		})
+	}
+}
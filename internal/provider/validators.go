@@ -6,9 +6,11 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // stringLengthValidator validates that a string attribute has a length within the specified range.
@@ -118,3 +120,117 @@ func (v stringNotEmptyValidator) ValidateString(ctx context.Context, request val
 func StringNotEmpty() validator.String {
 	return stringNotEmptyValidator{}
 }
+
+// stringOneOfValidator validates that a string attribute is one of a set of allowed values.
+type stringOneOfValidator struct {
+	values []string
+}
+
+func (v stringOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("string must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("string must be one of: `%s`", strings.Join(v.values, "`, `"))
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+	if !slices.Contains(v.values, value) {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid String Value",
+			fmt.Sprintf("Expected one of %s, got %q.", strings.Join(v.values, ", "), value),
+		)
+	}
+}
+
+// StringOneOf returns a validator which ensures that any configured attribute value.
+// matches one of the given values.
+func StringOneOf(values ...string) validator.String {
+	return stringOneOfValidator{
+		values: values,
+	}
+}
+
+// listStringsOneOfValidator validates that every element of a list of strings is one of a set of allowed values.
+type listStringsOneOfValidator struct {
+	values []string
+}
+
+func (v listStringsOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("list elements must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v listStringsOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("list elements must be one of: `%s`", strings.Join(v.values, "`, `"))
+}
+
+func (v listStringsOneOfValidator) ValidateList(ctx context.Context, request validator.ListRequest, response *validator.ListResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for i, element := range request.ConfigValue.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		if !slices.Contains(v.values, value.ValueString()) {
+			response.Diagnostics.AddAttributeError(
+				request.Path.AtListIndex(i),
+				"Invalid List Element",
+				fmt.Sprintf("Expected one of %s, got %q.", strings.Join(v.values, ", "), value.ValueString()),
+			)
+		}
+	}
+}
+
+// ListStringsOneOf returns a validator which ensures that every element of a configured.
+// list of strings matches one of the given values.
+func ListStringsOneOf(values ...string) validator.List {
+	return listStringsOneOfValidator{
+		values: values,
+	}
+}
+
+// int64AtLeastValidator validates that an int64 attribute is at least a minimum value.
+type int64AtLeastValidator struct {
+	min int64
+}
+
+func (v int64AtLeastValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be at least %d", v.min)
+}
+
+func (v int64AtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be at least `%d`", v.min)
+}
+
+func (v int64AtLeastValidator) ValidateInt64(ctx context.Context, request validator.Int64Request, response *validator.Int64Response) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueInt64()
+	if value < v.min {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Number Value",
+			fmt.Sprintf("Expected value to be at least %d, got %d.", v.min, value),
+		)
+	}
+}
+
+// Int64AtLeast returns a validator which ensures that any configured attribute value.
+// is greater than or equal to the given minimum.
+func Int64AtLeast(minValue int64) validator.Int64 {
+	return int64AtLeastValidator{
+		min: minValue,
+	}
+}